@@ -1,42 +1,55 @@
 //spellchecker:words spellchecker
 package spellchecker
 
-//spellchecker:words regexp strings golang tools analysis
+//spellchecker:words token regexp strings golang tools analysis
 import (
 	"go/ast"
+	"go/token"
 	"regexp"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
 
-// isDisabled checks if the spellchecker has been disabled for the given file
-func isDisabled(file *ast.File) bool {
-	for _, group := range file.Comments {
-		for _, comment := range group.List {
-			// ignore multi-line comments
-			if !strings.HasPrefix(comment.Text, "//") {
-				continue
-			}
-
-			// parse the directive
-			text := comment.Text[len("//"):]
-			_, directive, _, ok := ParseSpellComment(text)
-			if !ok {
-				continue
-			}
-			if strings.EqualFold(directive, "disable") {
-				return true
-			}
-		}
+// Analyzers returns all spellchecker analyzers, in the order they should be run.
+// The result is suitable for registering with gopls or multichecker.
+//
+// Diagnostics of the analyzers are categorized using [CategoryDirective] and [CategoryWord].
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		SpellcheckerPackageComments,
+		SpellcheckerImportComments,
+		SpellcheckerDeclarationComments,
+		SpellcheckerFileComments,
+		SpellcheckerWords,
+		SpellcheckerIdentifiers,
+		SpellcheckerComments,
+		SpellcheckerStrings,
+		SpellcheckerDirectives,
 	}
-	return false
 }
 
-var doNotEdit = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+// Categories of diagnostics reported by the analyzers.
+const (
+	// CategoryDirective is the category of diagnostics about missing, outdated or invalid directives.
+	// These typically come with a suggested fix, which editors offer as a quick fix.
+	CategoryDirective = "directive"
+
+	// CategoryWord is the category of diagnostics about unknown or forbidden words.
+	CategoryWord = "word"
+)
+
+// hasDirective checks if the given file contains a spellchecker directive with the given name.
+// The directive is compared under case folding.
+func hasDirective(cfg *Config, file *ast.File, name string) (found bool) {
+	forEachDirective(cfg, file, func(d directive) {
+		found = found || d.IsDirective(name)
+	})
+	return
+}
 
 // isDoNotEdit checks if the given file has a 'DO NOT EDIT' comment at the top.
-func isDoNotEdit(file *ast.File) bool {
+func isDoNotEdit(cfg *Config, file *ast.File) bool {
 	if len(file.Comments) == 0 {
 		return false
 	}
@@ -45,37 +58,116 @@ func isDoNotEdit(file *ast.File) bool {
 	if len(lst) == 0 {
 		return false
 	}
-	return doNotEdit.MatchString(lst[0].Text)
+	return cfg.generated.MatchString(lst[0].Text)
 }
 
-func parseWordComment(comment *ast.Comment) ([]string, bool) {
-	// ignore multi-line comments
-	if !strings.HasPrefix(comment.Text, "//") {
-		return nil, false
+// wordsDirectives returns the 'spellchecker:words' directives in the given comments.
+func wordsDirectives(cfg *Config, comments []*ast.Comment) []directive {
+	var directives []directive
+	for _, comment := range comments {
+		for _, d := range parseDirectives(cfg, comment) {
+			if d.IsDirective("words") {
+				directives = append(directives, d)
+			}
+		}
 	}
+	return directives
+}
+
+// WordsDirective is a 'spellchecker:words' directive in a file.
+type WordsDirective struct {
+	Comment *ast.Comment // the comment containing the directive
+	Pos     token.Pos    // position of the directive, may be inside a block comment
+	Words   []string     // the words listed in the directive
+}
+
+// WordsDirectives returns all 'spellchecker:words' directives in file.
+func (cfg *Config) WordsDirectives(file *ast.File) []WordsDirective {
+	var directives []WordsDirective
+	forEachDirective(cfg, file, func(d directive) {
+		if d.IsDirective("words") {
+			directives = append(directives, WordsDirective{Comment: d.comment, Pos: d.pos, Words: d.Words()})
+		}
+	})
+	return directives
+}
 
-	// parse the directive
-	text := comment.Text[len("//"):]
-	_, directive, value, ok := ParseSpellComment(text)
-	if !ok {
-		return nil, false
+// wordChecker checks the spelling of words.
+type wordChecker struct {
+	cfg     *Config          // the configuration in use
+	known   Dictionary       // words from 'words' and 'ignore' directives of the file
+	flagged Dictionary       // words from 'flagWords' directives of the file
+	ignore  []*regexp.Regexp // patterns from 'ignoreRegExp' directives of the file
+}
+
+// newWordChecker creates a new wordChecker for the given file.
+// It uses the configured dictionaries and the directives in the file.
+func newWordChecker(cfg *Config, file *ast.File) wordChecker {
+	wc := wordChecker{
+		cfg:     cfg,
+		known:   make(Dictionary),
+		flagged: make(Dictionary),
 	}
 
-	// ignore everything that isn't a word
-	if !strings.EqualFold(directive, "words") {
-		return nil, false
+	forEachDirective(cfg, file, func(d directive) {
+		switch {
+		case d.IsDirective("words"), d.IsDirective("ignore"):
+			wc.known.Add(d.Words()...)
+		case d.IsDirective("flagWords"):
+			wc.flagged.Add(d.Words()...)
+		case d.IsDirective("ignoreRegExp"):
+			// invalid patterns are reported by SpellcheckerDirectives
+			if pattern, err := d.RegExp(); err == nil {
+				wc.ignore = append(wc.ignore, pattern)
+			}
+		}
+	})
+	return wc
+}
+
+// Mask replaces all parts of text matching an 'ignoreRegExp' directive with spaces.
+// The returned string has the same length as text, so that offsets remain valid.
+func (wc wordChecker) Mask(text string) string {
+	for _, pattern := range wc.ignore {
+		text = maskPattern(text, pattern)
 	}
+	return text
+}
 
-	// split the words
-	return SplitWords(value), true
+// Check checks the spelling of word.
+//
+// It returns "forbidden" for words listed in a 'flagWords' directive, "unknown" for unknown words, and the empty string otherwise.
+// Words that are too short to be checked are never unknown.
+func (wc wordChecker) Check(word string) string {
+	word = strings.ReplaceAll(word, "’", "'")
+	switch {
+	case wc.flagged.ContainsExactly(word):
+		return "forbidden"
+	case len(word) < wc.cfg.MinWordLength || wc.cfg.isExcluded(word):
+		return ""
+	case wc.cfg.isKnownWord(word) || wc.known.Contains(word):
+		return ""
+	default:
+		return "unknown"
+	}
 }
 
-// edits for specific comments
+// reportDirective reports a problem with directive d that has no suggested fix.
+func reportDirective(pass *analysis.Pass, d directive, message string) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      d.pos,
+		End:      d.end,
+		Category: CategoryDirective,
+		Message:  message,
+	})
+}
 
 func removeComment(pass *analysis.Pass, comment *ast.Comment, message string, fix string) {
 	pass.Report(analysis.Diagnostic{
-		Pos:     comment.Pos(),
-		Message: message,
+		Pos:      comment.Pos(),
+		End:      comment.End(),
+		Category: CategoryDirective,
+		Message:  message,
 		SuggestedFixes: []analysis.SuggestedFix{
 			{
 				Message: fix,
@@ -91,6 +183,137 @@ func removeComment(pass *analysis.Pass, comment *ast.Comment, message string, fi
 	})
 }
 
+// removeDirective removes the directive d.
+// For directives in block comments, only the line containing the directive is removed,
+// unless the comment contains nothing else.
+func removeDirective(pass *analysis.Pass, d directive, message string, fix string) {
+	if !d.block {
+		removeComment(pass, d.comment, message, fix)
+		return
+	}
+
+	text := d.comment.Text
+	start, end := int(d.pos-d.comment.Pos()), int(d.end-d.comment.Pos())
+
+	// remove the entire comment if there is nothing else in it
+	if strings.Trim(text[:start]+text[end:], "/* \t\r\n") == "" {
+		removeComment(pass, d.comment, message, fix)
+		return
+	}
+
+	// remove the entire line, unless it contains the start or end of the comment
+	lineStart := strings.LastIndexByte(text[:start], '\n') + 1
+	if lineEnd := strings.IndexByte(text[end:], '\n'); lineStart > 0 && lineEnd >= 0 {
+		start, end = lineStart, end+lineEnd+1
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      d.pos,
+		End:      d.end,
+		Category: CategoryDirective,
+		Message:  message,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: fix,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     d.comment.Pos() + token.Pos(start),
+						End:     d.comment.Pos() + token.Pos(end),
+						NewText: nil,
+					},
+				},
+			},
+		},
+	})
+}
+
+// wantDirectiveText ensures that directive d has the given text.
+// For line comments, this is the text of the comment without the leading '//'.
+func wantDirectiveText(text string, pass *analysis.Pass, d directive, message string, fix string) bool {
+	if !d.block {
+		return wantComment(text, pass, d.comment, message, fix)
+	}
+
+	if d.comment.Text[d.pos-d.comment.Pos():d.end-d.comment.Pos()] == text {
+		return true
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      d.pos,
+		End:      d.end,
+		Category: CategoryDirective,
+		Message:  message,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: fix,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     d.pos,
+						End:     d.end,
+						NewText: []byte(text),
+					},
+				},
+			},
+		},
+	})
+	return false
+}
+
+// groupDirectives groups directives on consecutive lines into a single logical directive.
+// Directives in block comments are never grouped.
+func groupDirectives(fset *token.FileSet, directives []directive) (groups [][]directive) {
+	for i, d := range directives {
+		if i > 0 {
+			prev := directives[i-1]
+			if !d.block && !prev.block && fset.Position(d.pos).Line == fset.Position(prev.end).Line+1 {
+				groups[len(groups)-1] = append(groups[len(groups)-1], d)
+				continue
+			}
+		}
+		groups = append(groups, []directive{d})
+	}
+	return groups
+}
+
+// wantDirectiveLines ensures that the consecutive directives in group have the given lines of text.
+// See [wantDirectiveText].
+func wantDirectiveLines(lines []string, pass *analysis.Pass, group []directive, message string, fix string) bool {
+	if len(group) == 1 && (len(lines) == 1 || group[0].block) {
+		return wantDirectiveText(strings.Join(lines, "\n"), pass, group[0], message, fix)
+	}
+
+	if len(group) == len(lines) {
+		equal := true
+		for i, d := range group {
+			equal = equal && d.comment.Text == "//"+lines[i]
+		}
+		if equal {
+			return true
+		}
+	}
+
+	first, last := group[0].comment, group[len(group)-1].comment
+	pass.Report(analysis.Diagnostic{
+		Pos:      first.Pos(),
+		End:      last.End(),
+		Category: CategoryDirective,
+		Message:  message,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: fix,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     first.Pos(),
+						End:     last.End(),
+						NewText: []byte("//" + strings.Join(lines, "\n//")),
+					},
+				},
+			},
+		},
+	})
+	return false
+}
+
 // wantComment ensure that comment has the given text
 func wantComment(text string, pass *analysis.Pass, comment *ast.Comment, message string, fix string) bool {
 	want := "//" + text
@@ -99,8 +322,10 @@ func wantComment(text string, pass *analysis.Pass, comment *ast.Comment, message
 	}
 
 	pass.Report(analysis.Diagnostic{
-		Pos:     comment.Pos(),
-		Message: message,
+		Pos:      comment.Pos(),
+		End:      comment.End(),
+		Category: CategoryDirective,
+		Message:  message,
 		SuggestedFixes: []analysis.SuggestedFix{
 			{
 				Message: fix,
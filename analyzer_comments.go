@@ -0,0 +1,132 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token regexp strings golang tools analysis
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerComments = &analysis.Analyzer{
+	Name: "spellchecker_comments",
+	Doc:  "Checks that each word in a comment is a known word or listed in a 'spellchecker:words' directive",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) {
+				continue
+			}
+
+			// check the actual words in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeCommentWords(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// analyzeCommentWords checks the words of all comments in file.
+func analyzeCommentWords(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	checker := newWordChecker(cfg, file)
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			forEachCommentLine(cfg, comment, func(offset int, line string) {
+				text := maskProse(checker.Mask(line))
+				forEachProseWord(text, cfg.splitOptions(), func(wordOffset int, word string) {
+					pos := comment.Pos() + token.Pos(offset+wordOffset)
+					problem := checker.Check(word)
+					if problem == "" || sc.Disabled(pos) {
+						return
+					}
+
+					pass.Report(analysis.Diagnostic{
+						Pos:      pos,
+						End:      pos + token.Pos(len(word)),
+						Category: CategoryWord,
+						Message:  fmt.Sprintf("%s word %q in comment", problem, word),
+					})
+				})
+			})
+		}
+	}
+}
+
+//spellchecker:words nolint
+
+// goDirective matches comments that are directives to the go toolchain or other tools, such as '//go:generate' or '//nolint:all'.
+var goDirective = regexp.MustCompile(`^//(?:line |extern |export |[a-z0-9]+:[a-z0-9])`)
+
+// forEachCommentLine calls f for each line of prose in comment, along with the byte offset of the line.
+//
+// Directives, including spellchecker directives, and indented code blocks are skipped.
+func forEachCommentLine(cfg *Config, comment *ast.Comment, f func(offset int, line string)) {
+	// line comment
+	if text, ok := strings.CutPrefix(comment.Text, "//"); ok {
+		if goDirective.MatchString(comment.Text) || strings.HasPrefix(text, "\t") || strings.HasPrefix(text, "  ") {
+			return
+		}
+		if _, ok := cfg.Parse(text); ok {
+			return
+		}
+
+		f(len("//"), text)
+		return
+	}
+
+	// block comment
+	indent := blockIndent(comment.Text)
+	first := true
+	forEachBlockLine(comment.Text, func(offset int, line string) {
+		isCode := !first && isBlockCode(strings.TrimPrefix(line, indent))
+		first = false
+
+		_, text := trimBlockLine(line)
+		if _, ok := cfg.Parse(text); ok || isCode {
+			return
+		}
+		f(offset, line)
+	})
+}
+
+// blockIndent returns the indentation common to all non-blank lines of the block comment text.
+// The first line, which starts with '/*', is not considered.
+func blockIndent(text string) (indent string) {
+	first, found := true, false
+	forEachBlockLine(text, func(_ int, line string) {
+		if first || strings.TrimSpace(line) == "" {
+			first = false
+			return
+		}
+
+		lineIndent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			indent, found = lineIndent, true
+			return
+		}
+		for !strings.HasPrefix(lineIndent, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	})
+	return indent
+}
+
+// isBlockCode checks if a line of a block comment, with the common indentation removed, is part of an indented code block.
+// Like in line comments, lines decorated with a leading '*' are code if the '*' is followed by a tab or two spaces.
+func isBlockCode(line string) bool {
+	if rest, ok := strings.CutPrefix(line, "*"); ok {
+		return strings.HasPrefix(rest, "\t") || strings.HasPrefix(rest, "  ")
+	}
+	return strings.HasPrefix(line, "\t") || strings.HasPrefix(line, " ")
+}
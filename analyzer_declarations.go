@@ -0,0 +1,102 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token golang tools analysis
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerDeclarationComments = &analysis.Analyzer{
+	Name: "spellchecker_declaration_comments",
+	Doc:  "Checks that each top-level declaration has exactly one 'spellchecker:words' comment containing the unknown words in its identifiers. Only runs if enabled using the 'declarationDirectives' setting.",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		// declaration directives are opt-in
+		if !cfg.DeclarationDirectives {
+			return nil, nil
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) {
+				continue
+			}
+
+			// check the actual words in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeDeclarationWordDirectives(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// analyzeDeclarationWordDirectives analyzes all top-level func, type, const and var declarations in file.
+func analyzeDeclarationWordDirectives(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	for _, decl := range file.Decls {
+		if sc.Disabled(decl.Pos()) {
+			continue
+		}
+
+		var doc *ast.CommentGroup
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			doc = decl.Doc
+		default:
+			continue
+		}
+
+		doDocWords(pass, cfg, decl.Pos(), doc, makeDeclarationWords(pass, cfg, decl), "declaration")
+	}
+}
+
+// makeDeclarationWords returns the words of identifiers defined in decl that are not known to any dictionary.
+// If enabled in the configuration, also includes the unknown words of struct tags.
+// Words are returned in the order they first occur in.
+func makeDeclarationWords(pass *analysis.Pass, cfg *Config, decl ast.Decl) []string {
+	var words []string
+	seen := make(Dictionary)
+
+	add := func(text string) {
+		for _, word := range cfg.splitWords(text) {
+			if !cfg.isUnknownWord(word) || seen.ContainsExactly(word) {
+				continue
+			}
+			seen.Add(word)
+			words = append(words, word)
+		}
+	}
+
+	var tags map[*ast.BasicLit]struct{}
+	if cfg.StructTags {
+		tags = structTags(decl)
+	}
+
+	ast.Inspect(decl, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Ident:
+			if pass.TypesInfo.Defs[node] != nil {
+				add(node.Name)
+			}
+		case *ast.BasicLit:
+			if _, ok := tags[node]; ok {
+				forEachStructTagPart(node, func(_ token.Pos, part structTagPart) { add(part.text) })
+			}
+		}
+		return true
+	})
+
+	return words
+}
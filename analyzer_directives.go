@@ -0,0 +1,249 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words bytes regexp slices strings golang tools analysis
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerDirectives = &analysis.Analyzer{
+	Name: "spellchecker_directives",
+	Doc:  "Checks that all directives are known, and that 'ignore', 'flagWords', 'ignoreRegExp', 'language' and 'dictionaries' directives are valid and formatted correctly",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) {
+				continue
+			}
+
+			// check the directives in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeDirectives(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// languagePattern matches a single locale in a 'language' directive, such as 'en' or 'en-GB'.
+var languagePattern = regexp.MustCompile(`^(?:\*|[a-zA-Z]{2,3}(?:[-_][a-zA-Z0-9]{2,8})*)$`)
+
+// analyzeDirectives reports unknown directives, and validates and normalizes the 'ignore', 'flagWords', 'ignoreRegExp', 'language' and 'dictionaries' directives in file.
+//
+// If enabled in the configuration, also converts block comments containing only directives into line comments.
+func analyzeDirectives(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	converted := make(map[*ast.Comment]struct{})
+	if cfg.ConvertBlockDirectives {
+		converted = convertBlockDirectives(pass, cfg, sc, file)
+	}
+
+	forEachDirective(cfg, file, func(d directive) {
+		if sc.Disabled(d.pos) {
+			return
+		}
+
+		_, isConverted := converted[d.comment]
+		for _, problem := range validateDirective(cfg, d.CommentText, d.block) {
+			switch {
+			case problem.fix == "":
+				reportDirective(pass, d, problem.message)
+			case isConverted:
+				// converted directives are normalized as part of the conversion, other fixes apply once converted
+			case problem.text == "":
+				removeDirective(pass, d, problem.message, problem.fix)
+			default:
+				wantDirectiveText(problem.text, pass, d, problem.message, problem.fix)
+			}
+		}
+	})
+}
+
+// directiveProblem is a potential problem with a directive, see [validateDirective].
+type directiveProblem struct {
+	message string // message describing the problem
+	fix     string // message of the suggested fix, or empty if there is none
+
+	// text is the text the directive should have.
+	// If text is empty, the fix removes the directive.
+	// Otherwise the problem only exists if the directive does not already have this text.
+	text string
+}
+
+// validateDirective validates the directive ct.
+// Unknown directives are reported, and the 'ignore', 'flagWords', 'ignoreRegExp', 'language' and 'dictionaries' directives are validated and normalized.
+// All other directives are only normalized if normalize is true.
+func validateDirective(cfg *Config, ct CommentText, normalize bool) (problems []directiveProblem) {
+	name := canonicalDirective(ct.Directive)
+	if !slices.Contains(Directives, name) {
+		return []directiveProblem{unknownDirective(ct)}
+	}
+
+	empty := directiveProblem{message: fmt.Sprintf("empty '%s' directive", name), fix: "remove comment"}
+	switch name {
+	case "ignore", "flagWords":
+		words := ct.Words()
+		if len(words) == 0 {
+			return []directiveProblem{empty}
+		}
+		return []directiveProblem{formatDirective(name, strings.Join(words, " "))}
+
+	case "ignoreRegExp":
+		if ct.Value == "" {
+			return []directiveProblem{empty}
+		}
+		if _, err := ct.RegExp(); err != nil {
+			return []directiveProblem{{message: fmt.Sprintf("invalid regular expression in '%s' directive: %s", name, err)}}
+		}
+		return []directiveProblem{formatDirective(name, ct.Value)}
+
+	case "language", "locale":
+		languages := ct.Languages()
+		if len(languages) == 0 {
+			return []directiveProblem{empty}
+		}
+		for _, language := range languages {
+			if !languagePattern.MatchString(language) {
+				return []directiveProblem{{message: fmt.Sprintf("invalid language %q in '%s' directive", language, name)}}
+			}
+		}
+		return []directiveProblem{formatDirective(name, strings.Join(languages, ","))}
+
+	case "dictionaries":
+		dictionaries := ct.Dictionaries()
+		if len(dictionaries) == 0 {
+			return []directiveProblem{empty}
+		}
+		for _, dictionary := range dictionaries {
+			if !cfg.isDictionary(dictionary) {
+				problems = append(problems, directiveProblem{message: fmt.Sprintf("unknown dictionary %q in '%s' directive", dictionary, name)})
+			}
+		}
+		return append(problems, formatDirective(name, strings.Join(dictionaries, " ")))
+
+	default:
+		if !normalize {
+			return nil
+		}
+		return []directiveProblem{{
+			message: fmt.Sprintf("improperly formatted '%s' directive", name),
+			fix:     fmt.Sprintf("reformat '%s' directive", name),
+			text:    ct.Normalize().String(),
+		}}
+	}
+}
+
+// unknownDirective returns the problem of the unknown directive ct.
+// If there is a similar known directive, suggests replacing it.
+func unknownDirective(ct CommentText) directiveProblem {
+	closest, ok := closestDirective(ct.Directive)
+	if !ok {
+		return directiveProblem{message: fmt.Sprintf("unknown directive '%s'", ct.Directive)}
+	}
+
+	message := fmt.Sprintf("unknown directive '%s', did you mean '%s'?", ct.Directive, closest)
+	ct.Directive = closest
+	return directiveProblem{message: message, fix: fmt.Sprintf("replace with '%s'", closest), text: ct.String()}
+}
+
+// formatDirective returns the problem of a directive that does not contain the given directive and value in normalized form.
+func formatDirective(name, value string) directiveProblem {
+	return directiveProblem{
+		message: fmt.Sprintf("improperly formatted '%s' directive", name),
+		fix:     fmt.Sprintf("reformat '%s' directive", name),
+		text:    FormatDirective(name, value),
+	}
+}
+
+// convertBlockDirectives reports block comments that only contain directives, and suggests replacing them by line comments.
+// Comments are only converted if they are on lines of their own, and do not contain unknown directives.
+//
+// Returns the set of converted comments.
+func convertBlockDirectives(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) map[*ast.Comment]struct{} {
+	converted := make(map[*ast.Comment]struct{})
+	if pass.ReadFile == nil {
+		return converted
+	}
+
+	tokenFile := pass.Fset.File(file.Pos())
+	src, err := pass.ReadFile(tokenFile.Name())
+	if err != nil {
+		return converted
+	}
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if sc.Disabled(comment.Pos()) {
+				continue
+			}
+
+			// the comment must be on lines of its own
+			start, end := tokenFile.Offset(comment.Pos()), tokenFile.Offset(comment.End())
+			lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+			indent := string(src[lineStart:start])
+			rest, _, _ := bytes.Cut(src[end:], []byte("\n"))
+			if strings.TrimSpace(indent) != "" || len(bytes.TrimSpace(rest)) != 0 {
+				continue
+			}
+
+			// unknown directives are replaced by analyzeDirectives instead
+			if slices.ContainsFunc(parseDirectives(cfg, comment), func(d directive) bool {
+				return !slices.Contains(Directives, canonicalDirective(d.Directive))
+			}) {
+				continue
+			}
+
+			text, ok := convertBlockComment(cfg, comment, indent, func(ct CommentText) CommentText {
+				return normalizeDirective(cfg, ct)
+			})
+			if !ok {
+				continue
+			}
+			converted[comment] = struct{}{}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:      comment.Pos(),
+				End:      comment.End(),
+				Category: CategoryDirective,
+				Message:  "directives should use line comments",
+				SuggestedFixes: []analysis.SuggestedFix{
+					{
+						Message: "convert to line comments",
+						TextEdits: []analysis.TextEdit{
+							{
+								Pos:     comment.Pos(),
+								End:     comment.End(),
+								NewText: []byte(text),
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+	return converted
+}
+
+// normalizeDirective returns the normalized form of the known directive ct, with its value formatted as by validateDirective.
+func normalizeDirective(cfg *Config, ct CommentText) CommentText {
+	for _, problem := range validateDirective(cfg, ct, true) {
+		if problem.text == "" {
+			continue
+		}
+		if normalized, ok := cfg.Parse(problem.text); ok {
+			return normalized
+		}
+	}
+	return ct.Normalize()
+}
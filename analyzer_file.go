@@ -0,0 +1,80 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token golang tools analysis
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerFileComments = &analysis.Analyzer{
+	Name: "spellchecker_file_comments",
+	Doc:  "Checks that each file has exactly one 'spellchecker:words' comment in its header, containing the words in the package name, imports and the unknown words in identifiers. Only runs if the 'file' layout is configured.",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		// the split layout is handled by SpellcheckerPackageComments and SpellcheckerImportComments
+		if cfg.Layout != LayoutFile {
+			return nil, nil
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) {
+				continue
+			}
+
+			// check the actual words in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeFileWordDirective(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// analyzeFileWordDirective analyzes the single 'spellchecker:words' directive of file.
+// Directives of import declarations, as used by the split layout, are removed.
+func analyzeFileWordDirective(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	if sc.Disabled(file.Package) {
+		return
+	}
+
+	words := makePackageWords(cfg, file)
+
+	var imports []*ast.ImportSpec
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			imports = append(imports, spec.(*ast.ImportSpec))
+		}
+
+		// migrate from the split layout
+		if gen.Doc == nil || sc.Disabled(gen.Pos()) {
+			continue
+		}
+		for _, d := range wordsDirectives(cfg, gen.Doc.List) {
+			removeDirective(
+				pass, d,
+				"'spellchecker:words' directive in import doc is not used with the file layout",
+				"move words to the file directive",
+			)
+		}
+	}
+	words = append(words, makeImportWords(cfg, imports)...)
+
+	for _, decl := range file.Decls {
+		words = append(words, makeDeclarationWords(pass, cfg, decl)...)
+	}
+
+	doHeaderWords(pass, cfg, file, deduplicateWords(words), "file")
+}
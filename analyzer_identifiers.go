@@ -0,0 +1,105 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token golang tools analysis
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerIdentifiers = &analysis.Analyzer{
+	Name: "spellchecker_identifiers",
+	Doc:  "Checks that each word in an identifier declared in a file is a known word or listed in a 'spellchecker:words' directive",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) {
+				continue
+			}
+
+			// check the actual words in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeIdentifierWords(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// analyzeIdentifierWords checks the words of all identifiers declared in file.
+// If enabled in the configuration, also checks the words of struct tags.
+//
+// Identifiers that merely refer to a declaration are not checked,
+// as they are checked in the place they are declared in.
+func analyzeIdentifierWords(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	checker := newWordChecker(cfg, file)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if field, ok := node.(*ast.Field); ok && field.Tag != nil && cfg.StructTags {
+			analyzeStructTagWords(pass, checker, sc, field.Tag)
+			return true
+		}
+
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		// only check identifiers being declared
+		if _, ok := pass.TypesInfo.Defs[ident]; !ok || sc.Disabled(ident.Pos()) {
+			return true
+		}
+
+		forEachWord(checker.Mask(ident.Name), checker.cfg.splitOptions(), func(offset int, word string) {
+			problem := checker.Check(word)
+			if problem == "" {
+				return
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:      ident.Pos() + token.Pos(offset),
+				End:      ident.Pos() + token.Pos(offset+len(word)),
+				Category: CategoryWord,
+				Message:  fmt.Sprintf("%s word %q in identifier %q", problem, word, ident.Name),
+			})
+		})
+		return true
+	})
+}
+
+// analyzeStructTagWords checks the words in the keys and values of the struct tag lit.
+func analyzeStructTagWords(pass *analysis.Pass, checker wordChecker, sc scope, lit *ast.BasicLit) {
+	if sc.Disabled(lit.Pos()) {
+		return
+	}
+
+	forEachStructTagPart(lit, func(pos token.Pos, part structTagPart) {
+		forEachWord(checker.Mask(part.text), checker.cfg.splitOptions(), func(offset int, word string) {
+			problem := checker.Check(word)
+			if problem == "" {
+				return
+			}
+
+			// use the entire tag if the position of the part is unknown
+			wordPos, wordEnd := lit.Pos(), lit.End()
+			if pos.IsValid() {
+				wordPos, wordEnd = pos+token.Pos(offset), pos+token.Pos(offset+len(word))
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:      wordPos,
+				End:      wordEnd,
+				Category: CategoryWord,
+				Message:  fmt.Sprintf("%s word %q in struct tag %q", problem, word, part.key),
+			})
+		})
+	})
+}
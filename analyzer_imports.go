@@ -15,14 +15,25 @@ var SpellcheckerImportComments = &analysis.Analyzer{
 	Name: "spellchecker_import_comments",
 	Doc:  "Checks that each import declaration has exactly one 'spellchecker:words' comment containing the words in the imports",
 	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		// the file layout is handled by SpellcheckerFileComments
+		if cfg.Layout == LayoutFile {
+			return nil, nil
+		}
+
 		for _, file := range pass.Files {
 			// skip over files that say do not edit
-			if isDoNotEdit(file) || isDisabled(file) {
+			if isDoNotEdit(cfg, file) {
 				continue
 			}
 
 			// check the actual words in this file
-			analyzeImportWordDirective(pass, file)
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeImportWordDirective(pass, cfg, sc, file)
 		}
 
 		return nil, nil
@@ -30,11 +41,11 @@ var SpellcheckerImportComments = &analysis.Analyzer{
 }
 
 // analyzeImportWordDirective analyzes all import GenDecls for imports.
-func analyzeImportWordDirective(pass *analysis.Pass, file *ast.File) {
+func analyzeImportWordDirective(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
 	for _, decl := range file.Decls {
 		// ensure that we have a generic declaration
 		gen, ok := decl.(*ast.GenDecl)
-		if !ok || gen.Tok != token.IMPORT {
+		if !ok || gen.Tok != token.IMPORT || sc.Disabled(gen.Pos()) {
 			continue
 		}
 
@@ -45,70 +56,69 @@ func analyzeImportWordDirective(pass *analysis.Pass, file *ast.File) {
 		}
 
 		// deal with the spec
-		doImportSpecWords(pass, gen, specs)
+		doImportSpecWords(pass, cfg, gen, specs)
 	}
 
 }
 
 // doImportSpecWords handles words for the provided import declaration
-func doImportSpecWords(pass *analysis.Pass, decl *ast.GenDecl, specs []*ast.ImportSpec) {
+func doImportSpecWords(pass *analysis.Pass, cfg *Config, decl *ast.GenDecl, specs []*ast.ImportSpec) {
 	// if there are no specs, we don't need to do anything
 	if len(specs) == 0 {
 		return
 	}
 
-	// get the comments in the documentation of the import group
-	var comments []*ast.Comment
-	if decl.Doc != nil {
-		comments = make([]*ast.Comment, 0, len(decl.Doc.List))
-		for _, comment := range decl.Doc.List {
-			_, ok := parseWordComment(comment)
-			if !ok {
-				continue
-			}
-			comments = append(comments, comment)
-		}
-	}
+	doDocWords(pass, cfg, decl.Pos(), decl.Doc, makeImportWords(cfg, specs), "import")
+}
 
-	// find the comment we want
-	importWords := makeImportWords(specs)
+// doDocWords ensures that the documentation doc of the declaration at pos has exactly one 'spellchecker:words' directive containing words.
+// If words is empty, there should be no directive.
+// The kind of declaration is used in diagnostic messages.
+func doDocWords(pass *analysis.Pass, cfg *Config, pos token.Pos, doc *ast.CommentGroup, words []string, kind string) {
+	// get the directives in the documentation
+	var directives []directive
+	if doc != nil {
+		directives = wordsDirectives(cfg, doc.List)
+	}
+	groups := groupDirectives(pass.Fset, directives)
 
 	// want no comment, but there is one
-	if len(importWords) == 0 {
-		for _, comment := range comments {
-			removeComment(
-				pass, comment,
-				"'spellchecker:words' directive in import doc should only refer to import words",
+	if len(words) == 0 {
+		for _, d := range directives {
+			removeDirective(
+				pass, d,
+				fmt.Sprintf("'spellchecker:words' directive in %s doc should only refer to %s words", kind, kind),
 				"remove extra directive",
 			)
 		}
 
 		return
 
-	} else if len(comments) > 0 {
-		wantComment(
-			FormatDirective("words", strings.Join(importWords, " ")),
-			pass, comments[0],
-			"'spellchecker:words' directive in import doc should only contain import words",
-			"update import words directive",
+	} else if len(groups) > 0 {
+		wantDirectiveLines(
+			cfg.wrapWordsDirective(words),
+			pass, groups[0],
+			fmt.Sprintf("'spellchecker:words' directive in %s doc should only contain %s words", kind, kind),
+			fmt.Sprintf("update %s words directive", kind),
 		)
 	} else {
-		want := fmt.Sprintf("//%s\n", FormatDirective("words", strings.Join(importWords, " ")))
+		want := "//" + strings.Join(cfg.wrapWordsDirective(words), "\n//") + "\n"
 
 		// if the documentation has a preceding '//' comment, then insert a newline
-		if decl.Doc != nil && len(decl.Doc.List) > 0 && strings.HasPrefix(decl.Doc.List[0].Text, "//") {
+		if doc != nil && len(doc.List) > 0 && strings.HasPrefix(doc.List[0].Text, "//") {
 			want = "//\n" + want
 		}
 
 		pass.Report(analysis.Diagnostic{
-			Pos:     decl.Pos(),
-			Message: "missing 'spellchecker:words' directive in import doc",
+			Pos:      pos,
+			Category: CategoryDirective,
+			Message:  fmt.Sprintf("missing 'spellchecker:words' directive in %s doc", kind),
 			SuggestedFixes: []analysis.SuggestedFix{
 				{
-					Message: "insert 'spellchecker:words' directive in import doc",
+					Message: fmt.Sprintf("insert 'spellchecker:words' directive in %s doc", kind),
 					TextEdits: []analysis.TextEdit{
 						{
-							Pos:     decl.Pos(),
+							Pos:     pos,
 							End:     token.NoPos,
 							NewText: []byte(want),
 						},
@@ -119,21 +129,20 @@ func doImportSpecWords(pass *analysis.Pass, decl *ast.GenDecl, specs []*ast.Impo
 	}
 
 	// extra spellchecker:words comments should be remove
-	if len(comments) > 1 {
-		for _, comment := range comments[1:] {
-			removeComment(
-				pass, comment,
-				"there should be at most one 'spellchecker:words' directive in import doc",
-				"remove extra directive",
-			)
+	if len(groups) > 1 {
+		for _, group := range groups[1:] {
+			for _, d := range group {
+				removeDirective(
+					pass, d,
+					fmt.Sprintf("there should be at most one 'spellchecker:words' directive in %s doc", kind),
+					"remove extra directive",
+				)
+			}
 		}
 	}
 }
 
-// TODO: make this configurable
-const minWordLength = 4
-
-func makeImportWords(imports []*ast.ImportSpec) []string {
+func makeImportWords(cfg *Config, imports []*ast.ImportSpec) []string {
 	// guess the number of words for all the imports
 	sizeGuess := 5 * len(imports)
 
@@ -143,8 +152,8 @@ func makeImportWords(imports []*ast.ImportSpec) []string {
 
 	// a function to add some text to the known import words
 	add := func(text string) {
-		for _, word := range SplitWords(text) {
-			if len(word) < minWordLength {
+		for _, word := range cfg.splitWords(text) {
+			if !cfg.isDirectiveWord(word) {
 				continue
 			}
 			if _, ok := hadImportWords[word]; ok {
@@ -1,11 +1,12 @@
 //spellchecker:words spellchecker
 package spellchecker
 
-//spellchecker:words token strings pkglib collection golang tools analysis
+//spellchecker:words token slices strings pkglib collection golang tools analysis
 import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"slices"
 	"strings"
 
 	"go.tkw01536.de/pkglib/collection"
@@ -16,69 +17,92 @@ var SpellcheckerPackageComments = &analysis.Analyzer{
 	Name: "spellchecker_package_comments",
 	Doc:  "Checks that each package name has exactly one 'spellchecker:words' comment containing the words in the package name",
 	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		// the file layout is handled by SpellcheckerFileComments
+		if cfg.Layout == LayoutFile {
+			return nil, nil
+		}
+
 		for _, file := range pass.Files {
 			// skip over files that say do not edit
-			if isDoNotEdit(file) || isDisabled(file) {
+			if isDoNotEdit(cfg, file) {
 				continue
 			}
 
 			// check the actual words in this file
-			analyzePackageWordDirective(pass, file)
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzePackageWordDirective(pass, cfg, sc, file)
 		}
 
 		return nil, nil
 	},
 }
 
-func analyzePackageWordDirective(pass *analysis.Pass, file *ast.File) {
+func analyzePackageWordDirective(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	if sc.Disabled(file.Package) {
+		return
+	}
+
+	doHeaderWords(pass, cfg, file, makePackageWords(cfg, file), "package")
+}
 
-	// collect all the comments
-	comments := make([]*ast.Comment, 0)
+// makePackageWords returns the words in the package name of file, excluding the configured words.
+func makePackageWords(cfg *Config, file *ast.File) []string {
+	packageWords := collection.Deduplicate(cfg.splitWords(file.Name.Name))
+	return collection.KeepFunc(packageWords, func(word string) bool {
+		return cfg.isDirectiveWord(word) && !slices.ContainsFunc(cfg.PackageExcludeWords, func(exclude string) bool { return strings.EqualFold(word, exclude) })
+	})
+}
+
+// doHeaderWords ensures that the header of file, that is the comments before the package clause, has exactly one 'spellchecker:words' directive containing words.
+// The kind of words is used in diagnostic messages.
+func doHeaderWords(pass *analysis.Pass, cfg *Config, file *ast.File, words []string, kind string) {
+	// collect all the directives
+	var comments []*ast.Comment
 	for _, group := range file.Comments {
 		for _, comment := range group.List {
-			if comment.End() > file.Package {
-				continue
-			}
-			_, ok := parseWordComment(comment)
-			if !ok {
-				continue
+			if comment.End() <= file.Package {
+				comments = append(comments, comment)
 			}
-			comments = append(comments, comment)
 		}
 	}
-
-	// find the words in the package name, but explicitly exclude "test"
-	importWords := collection.Deduplicate(SplitWords(file.Name.Name))
-	importWords = collection.KeepFunc(importWords, func(word string) bool { return len(word) >= minWordLength && !strings.EqualFold(word, "test") })
+	directives := wordsDirectives(cfg, comments)
+	groups := groupDirectives(pass.Fset, directives)
 
 	// want no comment, but there is one
-	if len(importWords) == 0 {
-		for _, comment := range comments {
-			removeComment(
-				pass, comment,
-				"'spellchecker:words' directive in header should only refer to package words (of which there are none)",
+	if len(words) == 0 {
+		for _, d := range directives {
+			removeDirective(
+				pass, d,
+				fmt.Sprintf("'spellchecker:words' directive in header should only refer to %s words (of which there are none)", kind),
 				"remove extra directive",
 			)
 		}
 
 		return
 
-	} else if len(comments) > 0 {
-		wantComment(
-			FormatDirective("words", strings.Join(importWords, " ")),
-			pass, comments[0],
-			"'spellchecker:words' directive in header doc should only contain package words",
-			"update package words directive",
+	} else if len(groups) > 0 {
+		wantDirectiveLines(
+			cfg.wrapWordsDirective(words),
+			pass, groups[0],
+			fmt.Sprintf("'spellchecker:words' directive in header doc should only contain %s words", kind),
+			fmt.Sprintf("update %s words directive", kind),
 		)
 	} else {
-		want := fmt.Sprintf("//%s\n", FormatDirective("words", strings.Join(importWords, " ")))
-		if len(comments) > 1 {
+		want := "//" + strings.Join(cfg.wrapWordsDirective(words), "\n//") + "\n"
+		if len(directives) > 1 {
 			want = "//\n" + want
 		}
 
 		pass.Report(analysis.Diagnostic{
-			Pos:     file.Package,
-			Message: "missing 'spellchecker:words' directive for package documentation",
+			Pos:      file.Package,
+			End:      file.Name.End(),
+			Category: CategoryDirective,
+			Message:  "missing 'spellchecker:words' directive for package documentation",
 			SuggestedFixes: []analysis.SuggestedFix{
 				{
 					Message: "insert 'spellchecker:words' directive in package header",
@@ -95,13 +119,15 @@ func analyzePackageWordDirective(pass *analysis.Pass, file *ast.File) {
 	}
 
 	// extra spellchecker:words comments should be remove
-	if len(comments) > 1 {
-		for _, comment := range comments[1:] {
-			removeComment(
-				pass, comment,
-				"there should be at most one 'spellchecker:words' directive in header",
-				"remove extra directive",
-			)
+	if len(groups) > 1 {
+		for _, group := range groups[1:] {
+			for _, d := range group {
+				removeDirective(
+					pass, d,
+					"there should be at most one 'spellchecker:words' directive in header",
+					"remove extra directive",
+				)
+			}
 		}
 	}
 }
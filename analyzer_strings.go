@@ -0,0 +1,119 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token regexp slices golang tools analysis
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var SpellcheckerStrings = &analysis.Analyzer{
+	Name: "spellchecker_strings",
+	Doc:  "Checks that each word in a string literal is a known word or listed in a 'spellchecker:words' directive. Only runs on packages containing a 'spellchecker:check-strings' directive.",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		// packages must opt-in to string checking
+		if !slices.ContainsFunc(pass.Files, func(file *ast.File) bool { return hasDirective(cfg, file, "check-strings") }) {
+			return nil, nil
+		}
+
+		for _, file := range pass.Files {
+			// skip over files that say do not edit
+			if isDoNotEdit(cfg, file) || hasDirective(cfg, file, "ignore-strings") {
+				continue
+			}
+
+			// check the actual words in this file
+			sc, _ := newScope(cfg, pass.Fset, file)
+			analyzeStringWords(pass, cfg, sc, file)
+		}
+
+		return nil, nil
+	},
+}
+
+// analyzeStringWords checks the words of all string literals in file.
+// Import paths and struct tags are not checked.
+func analyzeStringWords(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	checker := newWordChecker(cfg, file)
+
+	tags := make(map[*ast.BasicLit]struct{})
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.Field:
+			if node.Tag != nil {
+				tags[node.Tag] = struct{}{}
+			}
+			return true
+		case *ast.BasicLit:
+			if _, isTag := tags[node]; isTag || node.Kind != token.STRING {
+				return true
+			}
+		default:
+			return true
+		}
+
+		lit := node.(*ast.BasicLit)
+		forEachProseWord(maskString(checker.Mask(lit.Value)), cfg.splitOptions(), func(offset int, word string) {
+			pos := lit.Pos() + token.Pos(offset)
+			problem := checker.Check(word)
+			if problem == "" || sc.Disabled(pos) {
+				return
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:      pos,
+				End:      pos + token.Pos(len(word)),
+				Category: CategoryWord,
+				Message:  fmt.Sprintf("%s word %q in string", problem, word),
+			})
+		})
+		return true
+	})
+}
+
+var (
+	escapeSequence = regexp.MustCompile(`\\(?:[abfnrtv\\'"]|[0-7]{3}|x[0-9a-fA-F]{2}|u[0-9a-fA-F]{4}|U[0-9a-fA-F]{8})`)
+	rawEscape      = regexp.MustCompile(`\\\S`)
+	formatVerb     = regexp.MustCompile(`%[-+# 0]*(?:\[\d+\])?(?:\d+|\*)?(?:\.(?:\d+|\*)?)?(?:\[\d+\])?[a-zA-Z%]`)
+	pathLike       = regexp.MustCompile(`\S*/\S*|\b[A-Za-z]:\\\S*|\.\.?\\\S*`)
+)
+
+// maskString replaces parts of the source of a string literal that should not be spell-checked with spaces.
+// These are escape sequences, format verbs, paths and everything masked by maskProse.
+//
+// Paths are tokens containing a '/', or starting with a drive letter ('C:\') or a relative directory ('.\' or '..\').
+// In raw strings, a backslash and the character following it are masked, as they typically form escape sequences of regular expressions or other languages.
+//
+// The returned string has the same length as literal, so that offsets remain valid.
+func maskString(literal string) string {
+	if len(literal) < 2 {
+		return literal
+	}
+
+	// mask paths first, as their separators look like escape sequences
+	interpreted := literal[0] == '"'
+	literal = maskPattern(literal, pathLike)
+	if interpreted {
+		literal = maskPattern(literal, escapeSequence)
+	} else {
+		literal = maskPattern(literal, rawEscape)
+	}
+
+	// remove the quotes, so they are not mistaken for a code span
+	literal = " " + literal[1:len(literal)-1] + " "
+
+	literal = maskPattern(literal, formatVerb)
+	return maskProse(literal)
+}
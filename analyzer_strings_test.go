@@ -0,0 +1,37 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words testing
+import (
+	"testing"
+)
+
+func TestMaskString(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    string
+	}{
+		{literal: `"hello world"`, want: ` hello world `},
+		{literal: `"hello\nworld"`, want: ` hello  world `},
+		{literal: "`hello\\nworld`", want: ` hello  world `},
+		{literal: "`^\\d+ items$`", want: ` ^  + items$ `},
+		{literal: `"value %s and %[1]q, %-10.2f"`, want: ` value    and      ,         `},
+		{literal: `"see /usr/lib/file here"`, want: ` see               here `},
+		{literal: `"/usr/lib"`, want: `          `},
+		{literal: `"open C:\\Windows\\System32 now"`, want: ` open                       now `},
+		{literal: "`run ..\\build\\tool.exe`", want: ` run                   `},
+		{literal: `"visit https://example.com"`, want: ` visit                     `},
+		{literal: `""`, want: `  `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			got := maskString(tt.literal)
+			if got != tt.want {
+				t.Errorf("maskString() = %q, want %q", got, tt.want)
+			}
+			if len(got) != len(tt.literal) {
+				t.Errorf("maskString() changed length from %d to %d", len(tt.literal), len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token regexp strconv strings testing golang tools analysis analysistest
+import (
+	"go/token"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzers(t *testing.T) {
+	analyzers := Analyzers()
+	if err := analysis.Validate(analyzers); err != nil {
+		t.Fatalf("Analyzers() are invalid: %v", err)
+	}
+
+	names := make(map[string]bool, len(analyzers))
+	for _, analyzer := range analyzers {
+		if names[analyzer.Name] {
+			t.Errorf("Analyzers() contains %q more than once", analyzer.Name)
+		}
+		names[analyzer.Name] = true
+	}
+}
+
+// analyzerTests are the packages in testdata that each analyzer is run on.
+var analyzerTests = []struct {
+	analyzer *analysis.Analyzer
+	pkg      string
+	category string
+}{
+	{SpellcheckerPackageComments, "packagecomments", CategoryDirective},
+	{SpellcheckerImportComments, "importcomments", CategoryDirective},
+	{SpellcheckerFileComments, "tofile", CategoryDirective},
+	{SpellcheckerFileComments, "wrapwords", CategoryDirective},
+	{SpellcheckerFileComments, "structtags/file", CategoryDirective},
+	{SpellcheckerPackageComments, "tosplit/packagecomments", CategoryDirective},
+	{SpellcheckerImportComments, "tosplit/importcomments", CategoryDirective},
+	{SpellcheckerDirectives, "directives", CategoryDirective},
+	{SpellcheckerDirectives, "blockdirectives", CategoryDirective},
+	{SpellcheckerDeclarationComments, "declarations", CategoryDirective},
+	{SpellcheckerDeclarationComments, "structtags/declarations", CategoryDirective},
+	{SpellcheckerDeclarationComments, "nostructtags", CategoryDirective},
+	{SpellcheckerWords, "words", CategoryDirective},
+	{SpellcheckerWords, "sortwords", CategoryDirective},
+	{SpellcheckerIdentifiers, "identifiers", CategoryWord},
+	{SpellcheckerIdentifiers, "structtags", CategoryWord},
+	{SpellcheckerIdentifiers, "nostructtags", CategoryWord},
+	{SpellcheckerComments, "comments", CategoryWord},
+	{SpellcheckerStrings, "strings", CategoryWord},
+	{SpellcheckerStrings, "nostrings", CategoryWord},
+}
+
+func TestAnalyzers_suggestedFixes(t *testing.T) {
+	// every analyzer has at least one test
+	tested := make(map[*analysis.Analyzer]bool, len(analyzerTests))
+	for _, tt := range analyzerTests {
+		tested[tt.analyzer] = true
+	}
+	for _, analyzer := range Analyzers() {
+		if !tested[analyzer] {
+			t.Errorf("analyzer %q is not run on any test package", analyzer.Name)
+		}
+	}
+
+	for _, tt := range analyzerTests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			results := analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), tt.analyzer, tt.pkg)
+
+			// all diagnostics are categorized, so that editors offer fixes of directives as quick fixes
+			for _, result := range results {
+				for _, diagnostic := range result.Diagnostics {
+					if diagnostic.Category != tt.category {
+						t.Errorf("diagnostic %q has category %q, want %q", diagnostic.Message, diagnostic.Category, tt.category)
+					}
+					if diagnostic.Category == CategoryWord {
+						checkWordRange(t, result.Pass.Fset, diagnostic)
+					}
+				}
+			}
+		})
+	}
+}
+
+// reportedWord matches the quoted word of a diagnostic about a word.
+var reportedWord = regexp.MustCompile(`word ("(?:[^"\\]|\\.)*")`)
+
+// checkWordRange checks that diagnostic, which is about a word, spans exactly that word in the source.
+func checkWordRange(t *testing.T, fset *token.FileSet, diagnostic analysis.Diagnostic) {
+	t.Helper()
+
+	match := reportedWord.FindStringSubmatch(diagnostic.Message)
+	if match == nil {
+		t.Errorf("diagnostic %q does not report a word", diagnostic.Message)
+		return
+	}
+	word, err := strconv.Unquote(match[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := fset.File(diagnostic.Pos)
+	src, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(src[file.Offset(diagnostic.Pos):file.Offset(diagnostic.End)])
+
+	// words of struct tags that do not occur literally are reported for the entire tag
+	if strings.Contains(diagnostic.Message, " in struct tag ") && strings.ContainsRune("\"`", rune(got[0])) {
+		if tag, err := strconv.Unquote(got); err == nil && strings.Contains(strings.ToLower(tag), strings.ToLower(word)) {
+			return
+		}
+	}
+
+	if got != word {
+		t.Errorf("%s: diagnostic %q spans %q", fset.Position(diagnostic.Pos), diagnostic.Message, got)
+	}
+}
@@ -1,9 +1,12 @@
 //spellchecker:words spellchecker
 package spellchecker
 
-//spellchecker:words strings golang tools analysis
+//spellchecker:words token strconv strings golang tools analysis
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -11,16 +14,23 @@ import (
 
 var SpellcheckerWords = &analysis.Analyzer{
 	Name: "spellchecker_word_comments",
-	Doc:  "Checks that each 'spellchecker:words' comment is formatted correctly and not empty",
+	Doc:  "Checks that each 'spellchecker:words' comment is formatted correctly, not empty and only lists words occurring in the file, and that 'disable' and 'enable' directives are balanced",
 	Run: func(pass *analysis.Pass) (interface{}, error) {
+		cfg, err := passConfig(pass)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, file := range pass.Files {
 			// skip over files that say do not edit
-			if isDoNotEdit(file) || isDisabled(file) {
+			if isDoNotEdit(cfg, file) {
 				continue
 			}
 
-			// check the actual words in this file
-			analyzeWordsDirectives(pass, file)
+			// check the scope and the actual words in this file
+			sc, problems := newScope(cfg, pass.Fset, file)
+			reportScopeErrors(pass, problems)
+			analyzeWordsDirectives(pass, cfg, sc, file)
 		}
 
 		return nil, nil
@@ -28,31 +38,205 @@ var SpellcheckerWords = &analysis.Analyzer{
 }
 
 // analyzeWordsDirectives processes all words directives for the given file
-func analyzeWordsDirectives(pass *analysis.Pass, file *ast.File) {
+func analyzeWordsDirectives(pass *analysis.Pass, cfg *Config, sc scope, file *ast.File) {
+	managed := managedDirectiveComments(cfg, file)
+
+	// words listed in managed directives, or in earlier free-standing ones
+	listed := make(Dictionary)
+	forEachDirective(cfg, file, func(d directive) {
+		if _, ok := managed[d.comment]; ok && d.IsDirective("words") {
+			listed.Add(d.Words()...)
+		}
+	})
+
+	var used Dictionary // words used in the file, computed only when needed
+	forEachDirective(cfg, file, func(d directive) {
+		if !d.IsDirective("words") || sc.Disabled(d.pos) {
+			return
+		}
+
+		// complain if there are no words, we should remove it
+		words := d.Words()
+		if len(words) == 0 {
+			removeDirective(
+				pass, d,
+				"empty words directive",
+				"remove comment",
+			)
+			return
+		}
+
+		// managed directives only need to be formatted
+		if _, ok := managed[d.comment]; ok {
+			wantWordsDirective(pass, cfg, d, words)
+			return
+		}
+
+		// prune duplicates, words listed elsewhere, and words that no longer occur in the file
+		if used == nil {
+			used = fileWords(cfg, file)
+		}
+
+		var kept, duplicate, elsewhere, stale []string
+		seen := make(Dictionary, len(words))
+		for _, word := range words {
+			switch {
+			case seen.ContainsExactly(word):
+				duplicate = append(duplicate, strconv.Quote(word))
+			case listed.ContainsExactly(word):
+				elsewhere = append(elsewhere, strconv.Quote(word))
+			case !usesWord(used, word):
+				stale = append(stale, strconv.Quote(word))
+			default:
+				kept = append(kept, word)
+			}
+			seen.Add(word)
+		}
+		listed.Add(kept...)
+
+		var problems []string
+		if len(duplicate) > 0 {
+			problems = append(problems, wordsMessage(duplicate, "is listed more than once", "are listed more than once"))
+		}
+		if len(elsewhere) > 0 {
+			problems = append(problems, wordsMessage(elsewhere, "is already listed in another 'words' directive", "are already listed in another 'words' directive"))
+		}
+		if len(stale) > 0 {
+			problems = append(problems, wordsMessage(stale, "does not occur in the file", "do not occur in the file"))
+		}
+		if len(problems) == 0 {
+			wantWordsDirective(pass, cfg, d, words)
+			return
+		}
+
+		message := "'words' directive: " + strings.Join(problems, "; ")
+		if len(kept) == 0 {
+			removeDirective(pass, d, message, "remove directive")
+			return
+		}
+		wantDirectiveText(
+			cfg.formatWordsDirective(kept),
+			pass, d,
+			message,
+			"remove words",
+		)
+	})
+}
+
+// usesWord reports if used contains word, or an inflection of it.
+// Inflections count as uses because the identifier analyzer accepts them as known words.
+func usesWord(used Dictionary, word string) bool {
+	if used.ContainsExactly(word) {
+		return true
+	}
+
+	listed := NewDictionary(word)
+	for other := range used {
+		if listed.Contains(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// wantWordsDirective ensures that the words directive d is in canonical form.
+func wantWordsDirective(pass *analysis.Pass, cfg *Config, d directive, words []string) {
+	wantDirectiveText(
+		cfg.formatWordsDirective(words),
+		pass, d,
+		"improperly formatted 'words' directive",
+		"reformat 'words' directive",
+	)
+}
+
+// wordsMessage formats a message about the given quoted words, using singular or plural depending on their number.
+func wordsMessage(words []string, singular, plural string) string {
+	if len(words) == 1 {
+		return fmt.Sprintf("word %s %s", words[0], singular)
+	}
+	return fmt.Sprintf("words %s %s", strings.Join(words, ", "), plural)
+}
+
+// managedDirectiveComments returns the comments that may contain 'spellchecker:words' directives managed by other analyzers.
+// These are the comments in the header of file, the documentation of import declarations, and, if enabled, the documentation of other top-level declarations.
+func managedDirectiveComments(cfg *Config, file *ast.File) map[*ast.Comment]struct{} {
+	managed := make(map[*ast.Comment]struct{})
+	add := func(doc *ast.CommentGroup) {
+		if doc == nil {
+			return
+		}
+		for _, comment := range doc.List {
+			managed[comment] = struct{}{}
+		}
+	}
+
 	for _, group := range file.Comments {
-		for _, comment := range group.List {
-			words, ok := parseWordComment(comment)
-			if !ok {
-				continue
+		if group.End() <= file.Package {
+			add(group)
+		}
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT || cfg.DeclarationDirectives {
+				add(decl.Doc)
+			}
+		case *ast.FuncDecl:
+			if cfg.DeclarationDirectives {
+				add(decl.Doc)
 			}
+		}
+	}
+	return managed
+}
+
+// fileWords returns the words occurring in identifiers, string literals and comments of file.
+// Spellchecker directives are not considered part of the file.
+func fileWords(cfg *Config, file *ast.File) Dictionary {
+	words := make(Dictionary)
 
-			// complain if there are no words, we should remove it
-			if len(words) == 0 {
-				removeComment(
-					pass, comment,
-					"empty words directive",
-					"remove comment",
-				)
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Ident:
+			words.Add(cfg.splitWords(node.Name)...)
+		case *ast.BasicLit:
+			if node.Kind == token.STRING {
+				words.Add(cfg.splitWords(node.Value)...)
+			}
+		case *ast.CommentGroup:
+			return false // handled below
+		}
+		return true
+	})
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if text, ok := strings.CutPrefix(comment.Text, "//"); ok {
+				if _, isDirective := cfg.Parse(text); !isDirective {
+					words.Add(cfg.splitWords(text)...)
+				}
 				continue
 			}
 
-			// ensure that the directive is spelled correctly
-			wantComment(
-				FormatDirective("words", strings.Join(words, " ")),
-				pass, comment,
-				"improperly formatted 'words' directive",
-				"reformat 'words' directive",
-			)
+			forEachBlockLine(comment.Text, func(_ int, line string) {
+				_, text := trimBlockLine(line)
+				if _, isDirective := cfg.Parse(text); !isDirective {
+					words.Add(cfg.splitWords(line)...)
+				}
+			})
 		}
 	}
+	return words
+}
+
+// reportScopeErrors reports problems with the 'disable' and 'enable' directives of a file.
+func reportScopeErrors(pass *analysis.Pass, errors []scopeError) {
+	for _, err := range errors {
+		if err.remove {
+			removeDirective(pass, err.directive, err.message, "remove directive")
+			continue
+		}
+
+		reportDirective(pass, err.directive, err.message)
+	}
 }
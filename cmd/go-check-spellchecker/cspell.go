@@ -0,0 +1,236 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words errors flag parser token path filepath slices strings check spellchecker
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+const cspellUsage = `Usage: go-check-spellchecker cspell [flags] sync|redundant [directories]
+
+Synchronizes 'spellchecker:words' directives in all Go files found in the given
+directories (default: the current directory) with a cspell configuration file.
+
+  sync       appends all words from directives that the cspell configuration
+             does not list yet, creating it if it does not exist; with
+             -prune, also removes words listed in no directive
+  redundant  reports words in directives already listed in the cspell
+             configuration, and exits with code 1 if there are any
+
+Flags may be given before or after the command and directories.
+Configurations containing comments can be read, but not written back.
+
+Flags:
+`
+
+// cspellMain implements the 'cspell' subcommand.
+func cspellMain(args []string) int {
+	flags := flag.NewFlagSet("cspell", flag.ExitOnError)
+	config := flags.String("config", "cspell.json", "path to the cspell configuration file")
+	ignore := flags.Bool("ignore", false, "add words to 'ignoreWords' instead of 'words' (sync only)")
+	prune := flags.Bool("prune", false, "remove words from 'words' (or 'ignoreWords' with -ignore) that are not listed in any directive (sync only)")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), cspellUsage)
+		flags.PrintDefaults()
+	}
+	positional := parseArgs(flags, args)
+
+	if len(positional) < 1 {
+		flags.Usage()
+		return 2
+	}
+
+	dirs := positional[1:]
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseGoFiles(fset, dirs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch positional[0] {
+	case "sync":
+		err = cspellSync(*config, *ignore, *prune, files)
+	case "redundant":
+		var redundant bool
+		redundant, err = cspellRedundant(*config, fset, files)
+		if err == nil && redundant {
+			return 1
+		}
+	default:
+		flags.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// goFile is a parsed go source file along with its configuration.
+type goFile struct {
+	cfg  *spellchecker.Config
+	file *ast.File
+}
+
+// parseGoFiles parses all go files in the given directories and their subdirectories.
+//
+// Like the go tool, it skips directories beginning with '.' or '_', 'testdata' and 'vendor' directories, and nested modules.
+func parseGoFiles(fset *token.FileSet, dirs []string) ([]goFile, error) {
+	var files []goFile
+	for _, root := range dirs {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() {
+				if path != root && skipDir(path) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+
+			cfg, err := spellchecker.LoadConfig(filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+
+			file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+
+			files = append(files, goFile{cfg: cfg, file: file})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// skipDir checks if the directory at path should be skipped when looking for go files.
+func skipDir(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+		return true
+	}
+
+	_, err := os.Stat(filepath.Join(path, "go.mod"))
+	return err == nil
+}
+
+// readCSpellConfig reads the cspell configuration at path.
+// If the file does not exist and create is true, returns a new configuration instead.
+func readCSpellConfig(path string, create bool) (*spellchecker.CSpellConfig, error) {
+	cs, err := spellchecker.ReadCSpellConfig(path)
+	if create && errors.Is(err, fs.ErrNotExist) {
+		return spellchecker.NewCSpellConfig(), nil
+	}
+	return cs, err
+}
+
+// cspellSync appends all words from directives in files to the cspell configuration at path, unless they are already known.
+// Existing words retain their order.
+// If prune is true, words of the target list that are not listed in any directive are removed.
+func cspellSync(path string, ignore, prune bool, files []goFile) error {
+	cs, err := readCSpellConfig(path, true)
+	if err != nil {
+		return err
+	}
+
+	target := &cs.Words
+	if ignore {
+		target = &cs.IgnoreWords
+	}
+
+	listed := make(spellchecker.Dictionary)
+	for _, f := range files {
+		for _, directive := range f.cfg.WordsDirectives(f.file) {
+			listed.Add(directive.Words...)
+		}
+	}
+
+	removed := 0
+	if prune {
+		*target = slices.DeleteFunc(*target, func(word string) bool {
+			if listed.ContainsExactly(word) {
+				return false
+			}
+			removed++
+			return true
+		})
+	}
+
+	known := cs.Known()
+	added := 0
+	for _, f := range files {
+		for _, directive := range f.cfg.WordsDirectives(f.file) {
+			for _, word := range directive.Words {
+				if known.ContainsExactly(word) {
+					continue
+				}
+				known.Add(word)
+				*target = append(*target, word)
+				added++
+			}
+		}
+	}
+
+	if err := cs.WriteFile(path); err != nil {
+		return err
+	}
+	if prune {
+		fmt.Fprintf(os.Stderr, "added %d and removed %d word(s) in %s\n", added, removed, path)
+	} else {
+		fmt.Fprintf(os.Stderr, "added %d word(s) to %s\n", added, path)
+	}
+	return nil
+}
+
+// cspellRedundant reports all words in directives of files that are already contained in the cspell configuration at path.
+// It reports if any redundant word was found.
+func cspellRedundant(path string, fset *token.FileSet, files []goFile) (bool, error) {
+	cs, err := readCSpellConfig(path, false)
+	if err != nil {
+		return false, err
+	}
+
+	known := cs.Known()
+	redundant := false
+	for _, f := range files {
+		for _, directive := range f.cfg.WordsDirectives(f.file) {
+			for _, word := range directive.Words {
+				if !known.ContainsExactly(word) {
+					continue
+				}
+				redundant = true
+				fmt.Printf("%s: word %q in 'spellchecker:words' directive is already listed in %s\n", fset.Position(directive.Pos), word, path)
+			}
+		}
+	}
+	return redundant, nil
+}
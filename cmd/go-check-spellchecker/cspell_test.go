@@ -0,0 +1,83 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words flag token path filepath reflect testing check spellchecker
+import (
+	"flag"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+func TestCSpellSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		prune     bool
+		wantWords []string
+	}{
+		{name: "append", prune: false, wantWords: []string{"zeta", "stale", "alpha", "frobnicate", "widget"}},
+		{name: "prune", prune: true, wantWords: []string{"zeta", "alpha", "frobnicate", "widget"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := "package a\n\n//spellchecker:words zeta frobnicate\n//spellchecker:words alpha widget\n"
+			if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			config := filepath.Join(dir, "cspell.json")
+			if err := os.WriteFile(config, []byte(`{"version": "0.2", "words": ["zeta", "stale", "alpha"]}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := parseGoFiles(token.NewFileSet(), []string{dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cspellSync(config, false, tt.prune, files); err != nil {
+				t.Fatalf("cspellSync() error = %v", err)
+			}
+
+			cs, err := spellchecker.ReadCSpellConfig(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cs.Words, tt.wantWords) {
+				t.Errorf("cspellSync() words = %v, want %v", cs.Words, tt.wantWords)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantIgnore     bool
+	}{
+		{name: "flags first", args: []string{"-ignore", "sync", "a"}, wantPositional: []string{"sync", "a"}, wantIgnore: true},
+		{name: "flags after command", args: []string{"sync", "-ignore", "a"}, wantPositional: []string{"sync", "a"}, wantIgnore: true},
+		{name: "flags last", args: []string{"sync", "a", "-ignore"}, wantPositional: []string{"sync", "a"}, wantIgnore: true},
+		{name: "terminator", args: []string{"sync", "--", "-ignore"}, wantPositional: []string{"sync", "-ignore"}, wantIgnore: false},
+		{name: "no arguments", args: nil, wantPositional: nil, wantIgnore: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			ignore := flags.Bool("ignore", false, "")
+
+			positional := parseArgs(flags, tt.args)
+			if !reflect.DeepEqual(positional, tt.wantPositional) {
+				t.Errorf("parseArgs() = %q, want %q", positional, tt.wantPositional)
+			}
+			if *ignore != tt.wantIgnore {
+				t.Errorf("parseArgs() ignore = %v, want %v", *ignore, tt.wantIgnore)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words slices strings
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//spellchecker:words myers
+
+// diffContext is the number of unchanged lines shown around each change of a unified diff.
+const diffContext = 3
+
+// diffOp is a single line of an edit script.
+type diffOp struct {
+	kind byte   // ' ' for unchanged, '-' for deleted and '+' for inserted lines
+	line string // the line, including the trailing newline (if any)
+	a, b int    // index of the line in the old and new text, or the number of lines before it
+}
+
+// unifiedDiff returns a unified diff transforming oldText into newText.
+// If there are no changes, returns the empty string.
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var buffer strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// find the end of the hunk, merging changes with overlapping context
+		end := i + 1
+		for j := end; j < len(ops) && j-end <= 2*diffContext; j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			}
+		}
+
+		start := max(0, i-diffContext)
+		end = min(len(ops), end+diffContext)
+		hunk := ops[start:end]
+
+		if buffer.Len() == 0 {
+			fmt.Fprintf(&buffer, "--- %s\n+++ %s\n", oldName, newName)
+		}
+
+		var aCount, bCount int
+		for _, op := range hunk {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&buffer, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, aCount), hunkRange(hunk[0].b, bCount))
+
+		for _, op := range hunk {
+			buffer.WriteByte(op.kind)
+			buffer.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				buffer.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+	return buffer.String()
+}
+
+// hunkRange formats the range of lines of a hunk header.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits text into lines, each including its trailing newline.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a minimal edit script transforming a into b, using the algorithm by Myers.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+
+	// v[offset+k] is the furthest x reached on diagonal k, trace holds v before each round
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// backtrack through the trace to recover the edit script
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			ops = append(ops, diffOp{kind: ' ', line: a[x], a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{kind: '+', line: b[prevY], a: prevX, b: prevY})
+			} else {
+				ops = append(ops, diffOp{kind: '-', line: a[prevX], a: prevX, b: prevY})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(ops)
+	return ops
+}
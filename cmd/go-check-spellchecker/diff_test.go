@@ -0,0 +1,87 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words testing
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "unchanged",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "empty",
+			old:  "",
+			new:  "",
+			want: "",
+		},
+		{
+			name: "create",
+			old:  "",
+			new:  "a\nb\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "insert",
+			old:  "a\nb\nc\nd\ne\n",
+			new:  "a\nb\nc\nX\nd\ne\n",
+			want: "--- old\n+++ new\n@@ -1,5 +1,6 @@\n a\n b\n c\n+X\n d\n e\n",
+		},
+		{
+			name: "delete",
+			old:  "a\nb\nc\nd\ne\nf\ng\nh\n",
+			new:  "a\nb\nc\nd\nf\ng\nh\n",
+			want: "--- old\n+++ new\n@@ -2,7 +2,6 @@\n b\n c\n d\n-e\n f\n g\n h\n",
+		},
+		{
+			name: "remove",
+			old:  "a\nb\n",
+			new:  "",
+			want: "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "replace",
+			old:  "a\n",
+			new:  "b\n",
+			want: "--- old\n+++ new\n@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			name: "adjacent hunks are merged",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:  "X\n2\n3\n4\n5\n6\n7\nY\n",
+			want: "--- old\n+++ new\n@@ -1,8 +1,8 @@\n-1\n+X\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+Y\n",
+		},
+		{
+			name: "distant hunks are separate",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:  "X\n2\n3\n4\n5\n6\n7\n8\nY\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+X\n 2\n 3\n 4\n@@ -6,4 +6,4 @@\n 6\n 7\n 8\n-9\n+Y\n",
+		},
+		{
+			name: "no trailing newline",
+			old:  "a\nb",
+			new:  "a\nc",
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+		{
+			name: "trailing newline added",
+			old:  "a",
+			new:  "a\n",
+			want: "--- old\n+++ new\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unifiedDiff("old", "new", tt.old, tt.new); got != tt.want {
+				t.Errorf("unifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words flag path filepath slices strings check spellchecker
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+const filesUsage = `Usage: go-check-spellchecker files [flags] [paths]
+
+Checks the spellchecker directives in files other than Go source files, such as
+protocol buffers, SQL, Dockerfiles and shell scripts, in the given files and
+directories (default: .).
+
+Directives are found in comments, using the comment syntax of each file's
+extension. Files with an unknown extension are skipped, as are hidden,
+'vendor', 'testdata' and 'node_modules' directories.
+
+The header of each go.mod file must have a 'spellchecker:words' directive
+containing the words of all module paths.
+
+With -fix, suggested fixes are applied in place. Fixes that overlap another fix
+of the same file are not applied; their problems are printed, and remain until
+the command is run again.
+
+Exits with code 1 if any problem remains, and with code 2 if files could not be
+read or written.
+
+Flags:
+`
+
+// fileSyntaxes maps file extensions, or entire file names, to their comment syntax.
+var fileSyntaxes = map[string]spellchecker.CommentSyntax{
+	".proto":      {Line: "//", BlockStart: "/*", BlockEnd: "*/"},
+	".sql":        {Line: "--", BlockStart: "/*", BlockEnd: "*/"},
+	".sh":         {Line: "#"},
+	".bash":       {Line: "#"},
+	".zsh":        {Line: "#"},
+	".yaml":       {Line: "#"},
+	".yml":        {Line: "#"},
+	".toml":       {Line: "#"},
+	".py":         {Line: "#"},
+	".dockerfile": {Line: "#"},
+	"Dockerfile":  {Line: "#"},
+	"Makefile":    {Line: "#"},
+	".md":         {BlockStart: "<!--", BlockEnd: "-->"},
+	".html":       {BlockStart: "<!--", BlockEnd: "-->"},
+}
+
+// skippedDirectories are the names of directories never searched for files.
+var skippedDirectories = []string{"vendor", "testdata", "node_modules"}
+
+// filesMain implements the 'files' subcommand.
+func filesMain(args []string) int {
+	flags := flag.NewFlagSet("files", flag.ExitOnError)
+	fix := flags.Bool("fix", false, "apply suggested fixes in place")
+	flags.Func("comment", "set the comment syntax of a file extension or name, in the form 'ext=syntax', e.g. '.ini=;', '.vue=<!-- -->' or '.sql=-- /* */' (may be repeated)", func(value string) error {
+		ext, text, ok := strings.Cut(value, "=")
+		if !ok || ext == "" {
+			return fmt.Errorf("expected 'ext=syntax', got %q", value)
+		}
+		syntax, err := spellchecker.ParseCommentSyntax(text)
+		if err != nil {
+			return err
+		}
+		fileSyntaxes[ext] = syntax
+		return nil
+	})
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), filesUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args) // flags exits on error
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var failed, remaining bool
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() {
+				if name != path && (strings.HasPrefix(entry.Name(), ".") || slices.Contains(skippedDirectories, entry.Name())) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			check, ok := fileCheck(name)
+			if !ok {
+				return nil
+			}
+
+			problems, err := checkFile(name, check, *fix)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+			}
+			remaining = remaining || problems
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+		}
+	}
+
+	switch {
+	case failed:
+		return 2
+	case remaining:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fileChecker checks the contents of a file.
+type fileChecker func(cfg *spellchecker.Config, name string, src []byte) ([]spellchecker.TextDiagnostic, error)
+
+// fileCheck returns the checker for the file with the given name.
+// For files other than go.mod, the entire base name takes precedence over the extension to determine the comment syntax.
+func fileCheck(name string) (fileChecker, bool) {
+	base := filepath.Base(name)
+	if base == "go.mod" {
+		return (*spellchecker.Config).CheckModFile, true
+	}
+
+	syntax, ok := fileSyntaxes[base]
+	if !ok {
+		syntax, ok = fileSyntaxes[filepath.Ext(base)]
+	}
+	if !ok {
+		return nil, false
+	}
+	return func(cfg *spellchecker.Config, _ string, src []byte) ([]spellchecker.TextDiagnostic, error) {
+		return cfg.CheckText(string(src), syntax), nil
+	}, true
+}
+
+// checkFile checks the file with the given name using check, and prints all problems.
+// If fix is true, suggested fixes are applied in place, and only problems without a fix are printed.
+// Fixes that overlap an earlier fix are not applied, and their problems are printed as well.
+//
+// Reports if any problem was printed.
+func checkFile(name string, check fileChecker, fix bool) (problems bool, err error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return false, err
+	}
+
+	cfg, err := spellchecker.LoadConfig(filepath.Dir(name))
+	if err != nil {
+		return false, err
+	}
+
+	diagnostics, err := check(cfg, name, src)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		fixes [][]fileEdit
+		fixed []spellchecker.TextDiagnostic // the diagnostic of each fix
+	)
+	for _, diagnostic := range diagnostics {
+		if fix && diagnostic.Fix != nil {
+			fixes = append(fixes, []fileEdit{{start: diagnostic.Fix.Start, end: diagnostic.Fix.End, text: diagnostic.Fix.NewText}})
+			fixed = append(fixed, diagnostic)
+			continue
+		}
+
+		printDiagnostic(name, src, diagnostic, "")
+		problems = true
+	}
+
+	if len(fixes) == 0 {
+		return problems, nil
+	}
+
+	result, skipped := applyFixes(src, fixes)
+	for _, index := range skipped {
+		printDiagnostic(name, src, fixed[index], " (fix overlaps another fix, run again to apply it)")
+		problems = true
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return problems, err
+	}
+	if err := os.WriteFile(name, []byte(result), info.Mode().Perm()); err != nil {
+		return problems, fmt.Errorf("%s: %w", name, err)
+	}
+	return problems, nil
+}
+
+// printDiagnostic prints diagnostic of the file with the given name and source, followed by note.
+func printDiagnostic(name string, src []byte, diagnostic spellchecker.TextDiagnostic, note string) {
+	line, column := lineColumn(src, diagnostic.Start)
+	fmt.Printf("%s:%d:%d: %s%s\n", name, line, column, diagnostic.Message, note)
+}
+
+// lineColumn returns the 1-based line and column (in bytes) of offset in src.
+func lineColumn(src []byte, offset int) (line, column int) {
+	lineStart := strings.LastIndexByte(string(src[:offset]), '\n') + 1
+	return strings.Count(string(src[:lineStart]), "\n") + 1, offset - lineStart + 1
+}
@@ -0,0 +1,89 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words path filepath testing check spellchecker
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+func TestFilesMain(t *testing.T) {
+	t.Cleanup(func() { delete(fileSyntaxes, ".ini") })
+
+	const (
+		hash      = "# spellchecker:words   zorblify\necho zorblify\n"
+		hashFixed = "# spellchecker:words zorblify\necho zorblify\n"
+	)
+
+	dir := t.TempDir()
+	files := map[string]struct{ src, want string }{
+		"script.sh":         {hash, hashFixed},
+		"Dockerfile":        {hash, hashFixed},
+		"sub/query.sql":     {"-- spellchecker:words   zorblify\n/* spellchecker:words  quxify */\nSELECT zorblify, quxify;\n", "-- spellchecker:words zorblify\n/* spellchecker:words quxify */\nSELECT zorblify, quxify;\n"},
+		"settings.ini":      {"x = 1 ; spellchecker:words   zorblify\n", "x = 1 ; spellchecker:words zorblify\n"},
+		"notes.txt":         {hash, hash}, // unknown extension
+		".hidden/a.sh":      {hash, hash},
+		"vendor/a.sh":       {hash, hash},
+		"testdata/a.sh":     {hash, hash},
+		"node_modules/a.sh": {hash, hash},
+	}
+	for name, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file.src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if code := filesMain([]string{"-comment", ".ini=;", dir}); code != 1 {
+		t.Errorf("files = %d, want 1", code)
+	}
+	for name, file := range files {
+		if got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name))); err != nil || string(got) != file.src {
+			t.Errorf("files changed %s to %q, %v", name, got, err)
+		}
+	}
+
+	if code := filesMain([]string{"-fix", "-comment", ".ini=;", dir}); code != 0 {
+		t.Errorf("files -fix = %d, want 0", code)
+	}
+	for name, file := range files {
+		if got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name))); err != nil || string(got) != file.want {
+			t.Errorf("files -fix changed %s to %q, %v, want %q", name, got, err, file.want)
+		}
+	}
+
+	if code := filesMain([]string{"-comment", ".ini=;", dir}); code != 0 {
+		t.Errorf("files after -fix = %d, want 0", code)
+	}
+}
+
+func TestCheckFile_overlapping(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(name, []byte("hello world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(*spellchecker.Config, string, []byte) ([]spellchecker.TextDiagnostic, error) {
+		return []spellchecker.TextDiagnostic{
+			{Start: 0, End: 5, Message: "first", Fix: &spellchecker.TextFix{Start: 0, End: 5, NewText: "hi"}},
+			{Start: 3, End: 8, Message: "second", Fix: &spellchecker.TextFix{Start: 3, End: 8, NewText: ""}},
+		}, nil
+	}
+
+	problems, err := checkFile(name, check, true)
+	if err != nil {
+		t.Fatalf("checkFile() error = %v", err)
+	}
+	if !problems {
+		t.Error("checkFile() did not report the skipped fix as a remaining problem")
+	}
+	if got, err := os.ReadFile(name); err != nil || string(got) != "hi world" {
+		t.Errorf("checkFile() wrote %q, %v, want %q", got, err, "hi world")
+	}
+}
@@ -0,0 +1,232 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words errors flag format token path filepath slices strings golang tools analysis checker packages
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+const fixUsage = `Usage: go-check-spellchecker fix [flags] [packages]
+
+Computes the suggested fixes of all analyzers for the given packages
+(default: ./...) and prints them as a unified diff, without modifying any files.
+
+Fixes that overlap other fixes are not included in the diff, and are reported
+on standard error instead; apply the diff and run again to compute them.
+
+Exits with code 1 if any file would be changed, including by skipped fixes, and
+with code 2 if the packages could not be analyzed.
+
+Flags:
+`
+
+// fixMain implements the 'fix' subcommand.
+func fixMain(args []string) int {
+	flags := flag.NewFlagSet("fix", flag.ExitOnError)
+	tests := flags.Bool("test", true, "also analyze test files")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), fixUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args) // flags exits on error
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	graph, err := analyzePackages(patterns, *tests)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	fixes, failed := collectFixes(graph)
+	changed, err := printFixes(fixes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	switch {
+	case failed:
+		return 2
+	case changed:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var errPackages = errors.New("failed to load packages")
+
+// analyzePackages loads the packages matching patterns and runs all analyzers on them.
+// Errors in the loaded packages are printed to standard error.
+func analyzePackages(patterns []string, tests bool) (*checker.Graph, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: tests}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errPackages
+	}
+
+	return checker.Analyze(analyzers, pkgs, nil)
+}
+
+// relativePath returns name relative to the directory wd, using forward slashes.
+// If name is not inside of wd, returns name unchanged.
+func relativePath(wd, name string) string {
+	if rel, err := filepath.Rel(wd, name); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return name
+}
+
+// fileEdit is a single edit of a file, using byte offsets.
+type fileEdit struct {
+	start, end int
+	text       string
+}
+
+// collectFixes collects the first suggested fix of each diagnostic, grouped by file name.
+// Errors of individual analyzers are printed, and reported as failed.
+func collectFixes(graph *checker.Graph) (fixes map[string][][]fileEdit, failed bool) {
+	fixes = make(map[string][][]fileEdit)
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", act, act.Err)
+			failed = true
+			continue
+		}
+
+		for _, diagnostic := range act.Diagnostics {
+			if len(diagnostic.SuggestedFixes) == 0 {
+				continue
+			}
+
+			name, fix := fixEdits(act.Package.Fset, diagnostic.SuggestedFixes[0])
+			if name == "" || slices.ContainsFunc(fixes[name], func(other []fileEdit) bool { return slices.Equal(fix, other) }) {
+				continue // test variants of a package report the same fixes
+			}
+			fixes[name] = append(fixes[name], fix)
+		}
+	}
+	return fixes, failed
+}
+
+// fixEdits converts the edits of fix into edits of a single file.
+// If the edits are not all in the same file, returns the empty string.
+func fixEdits(fset *token.FileSet, fix analysis.SuggestedFix) (name string, edits []fileEdit) {
+	for _, edit := range fix.TextEdits {
+		file := fset.File(edit.Pos)
+		if file == nil || (name != "" && name != file.Name()) {
+			return "", nil
+		}
+		name = file.Name()
+
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+		edits = append(edits, fileEdit{start: file.Offset(edit.Pos), end: file.Offset(end), text: string(edit.NewText)})
+	}
+	return name, edits
+}
+
+// applyFixes applies fixes to src and returns the result.
+// Fixes with edits overlapping those of an earlier fix are skipped, and their indices are returned.
+// Edits identical to those of an earlier fix are applied only once.
+func applyFixes(src []byte, fixes [][]fileEdit) (fixed string, skipped []int) {
+	var applied []fileEdit
+
+	overlaps := func(edit fileEdit) bool {
+		return slices.ContainsFunc(applied, func(other fileEdit) bool {
+			if edit == other {
+				return false
+			}
+			return edit.start < other.end && other.start < edit.end || edit.start == other.start
+		})
+	}
+
+	for i, fix := range fixes {
+		if slices.ContainsFunc(fix, overlaps) {
+			skipped = append(skipped, i)
+			continue
+		}
+		for _, edit := range fix {
+			if !slices.Contains(applied, edit) {
+				applied = append(applied, edit)
+			}
+		}
+	}
+
+	slices.SortStableFunc(applied, func(a, b fileEdit) int { return a.start - b.start })
+
+	var result strings.Builder
+	last := 0
+	for _, edit := range applied {
+		result.Write(src[last:edit.start])
+		result.WriteString(edit.text)
+		last = edit.end
+	}
+	result.Write(src[last:])
+	return result.String(), skipped
+}
+
+// printFixes prints a unified diff of all fixes to standard output, and reports if any file would change.
+// Fixes that overlap other fixes are not part of the diff; they are reported on standard error, and count as changes.
+func printFixes(fixes map[string][][]fileEdit) (changed bool, err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return false, err
+	}
+
+	names := make([]string, 0, len(fixes))
+	for name := range fixes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return changed, err
+		}
+
+		path := relativePath(wd, name)
+
+		// like multichecker, format the fixed file
+		fixed, skipped := applyFixes(src, fixes[name])
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %d overlapping fixes were skipped, run again after applying the diff\n", path, len(skipped))
+			changed = true
+		}
+		if formatted, err := format.Source([]byte(fixed)); err == nil {
+			fixed = string(formatted)
+		}
+
+		diff := unifiedDiff("a/"+path, "b/"+path, string(src), fixed)
+		if diff == "" {
+			continue
+		}
+		changed = true
+		fmt.Print(diff)
+	}
+	return changed, nil
+}
@@ -0,0 +1,92 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words reflect testing
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixes       [][]fileEdit
+		want        string
+		wantSkipped []int
+	}{
+		{
+			name: "no fixes",
+			want: "hello world",
+		},
+		{
+			name: "disjoint",
+			fixes: [][]fileEdit{
+				{{start: 6, end: 11, text: "there"}},
+				{{start: 0, end: 5, text: "hi"}},
+			},
+			want: "hi there",
+		},
+		{
+			name: "insertion",
+			fixes: [][]fileEdit{
+				{{start: 5, end: 5, text: ","}},
+			},
+			want: "hello, world",
+		},
+		{
+			name: "duplicate",
+			fixes: [][]fileEdit{
+				{{start: 0, end: 5, text: "hi"}},
+				{{start: 0, end: 5, text: "hi"}},
+			},
+			want: "hi world",
+		},
+		{
+			name: "duplicate edit in a larger fix",
+			fixes: [][]fileEdit{
+				{{start: 0, end: 5, text: "hi"}},
+				{{start: 0, end: 5, text: "hi"}, {start: 6, end: 11, text: "there"}},
+			},
+			want: "hi there",
+		},
+		{
+			name: "overlapping",
+			fixes: [][]fileEdit{
+				{{start: 0, end: 7, text: "bye"}},
+				{{start: 6, end: 11, text: "there"}},
+				{{start: 8, end: 11, text: "r"}},
+			},
+			want:        "byeor",
+			wantSkipped: []int{1},
+		},
+		{
+			name: "insertions at the same offset",
+			fixes: [][]fileEdit{
+				{{start: 5, end: 5, text: ","}},
+				{{start: 5, end: 5, text: "!"}},
+			},
+			want:        "hello, world",
+			wantSkipped: []int{1},
+		},
+		{
+			name: "partially overlapping fix is skipped entirely",
+			fixes: [][]fileEdit{
+				{{start: 0, end: 5, text: "hi"}},
+				{{start: 5, end: 5, text: ","}, {start: 3, end: 8, text: ""}},
+			},
+			want:        "hi world",
+			wantSkipped: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped := applyFixes([]byte("hello world"), tt.fixes)
+			if got != tt.want {
+				t.Errorf("applyFixes() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("applyFixes() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -1,16 +1,55 @@
 //spellchecker:words main
 package main
 
-//spellchecker:words check spellchecker golang tools analysis multichecker
+//spellchecker:words flag check spellchecker golang tools analysis multichecker
 import (
+	"flag"
+	"os"
+
 	spellchecker "go.tkw01536.de/go-check-spellchecker"
 	"golang.org/x/tools/go/analysis/multichecker"
 )
 
+// subcommands maps the names of subcommands to their implementation.
+// Each subcommand receives the remaining arguments, and returns an exit code.
+//
+// Any other invocation runs the analyzers using multichecker.
+var subcommands = map[string]func(args []string) int{
+	"cspell": cspellMain,
+	"files":  filesMain,
+	"fix":    fixMain,
+	"report": reportMain,
+}
+
+// analyzers are the analyzers run by this command.
+var analyzers = spellchecker.Analyzers()
+
 func main() {
-	multichecker.Main(
-		spellchecker.SpellcheckerPackageComments,
-		spellchecker.SpellcheckerImportComments,
-		spellchecker.SpellcheckerWords,
-	)
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
+	multichecker.Main(analyzers...)
+}
+
+// parseArgs parses flags from args, allowing flags to follow positional arguments.
+// It returns the positional arguments.
+// All arguments following a "--" are positional.
+func parseArgs(flags *flag.FlagSet, args []string) (positional []string) {
+	for {
+		_ = flags.Parse(args) // flags exits on error
+
+		rest := flags.Args()
+		if consumed := args[:len(args)-len(rest)]; len(consumed) > 0 && consumed[len(consumed)-1] == "--" {
+			return append(positional, rest...)
+		}
+		if len(rest) == 0 {
+			return positional
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
 }
@@ -0,0 +1,492 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words encoding json flag token path filepath slices strings unicode golang tools analysis checker
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+const reportUsage = `Usage: go-check-spellchecker report [flags] [packages]
+
+Runs all analyzers on the given packages (default: ./...) and writes the
+diagnostics in a machine-readable format:
+
+  json        a json array of diagnostics
+  sarif       a SARIF 2.1.0 log, with one rule per analyzer
+  checkstyle  a checkstyle XML report
+
+Exits with code 1 if there are any diagnostics, and with code 2 if the packages
+could not be analyzed.
+
+Flags:
+`
+
+// reporters maps the names of report formats to their implementation.
+var reporters = map[string]func(w io.Writer, diagnostics []reportDiagnostic) error{
+	"json":       writeJSON,
+	"sarif":      writeSARIF,
+	"checkstyle": writeCheckstyle,
+}
+
+// reportMain implements the 'report' subcommand.
+func reportMain(args []string) int {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	format := flags.String("format", "json", "output format, one of 'json', 'sarif' or 'checkstyle'")
+	output := flags.String("o", "", "write the report to this file instead of standard output")
+	tests := flags.Bool("test", true, "also analyze test files")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), reportUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args) // flags exits on error
+
+	reporter, ok := reporters[*format]
+	if !ok {
+		flags.Usage()
+		return 2
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	graph, err := analyzePackages(patterns, *tests)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	diagnostics, failed := collectDiagnostics(graph)
+
+	if err := writeReport(*output, reporter, diagnostics); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	switch {
+	case failed:
+		return 2
+	case len(diagnostics) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// writeReport writes diagnostics using reporter to the file at path.
+// If path is empty, writes to standard output instead.
+func writeReport(path string, reporter func(w io.Writer, diagnostics []reportDiagnostic) error, diagnostics []reportDiagnostic) error {
+	if path == "" {
+		return reporter(os.Stdout, diagnostics)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = reporter(file, diagnostics)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// reportDiagnostic is a diagnostic in a report.
+type reportDiagnostic struct {
+	Analyzer string      `json:"analyzer"`
+	Category string      `json:"category,omitempty"`
+	Range    reportRange `json:"range"`
+	Message  string      `json:"message"`
+	Fixes    []reportFix `json:"fixes,omitempty"`
+}
+
+// reportFix is a suggested fix of a diagnostic.
+type reportFix struct {
+	Message string       `json:"message"`
+	Edits   []reportEdit `json:"edits"`
+}
+
+// reportEdit is a single edit of a suggested fix.
+type reportEdit struct {
+	Range   reportRange `json:"range"`
+	NewText string      `json:"newText"`
+}
+
+// reportRange is a range of text in a file.
+// Lines and columns are 1-based, columns are counted in bytes.
+type reportRange struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+}
+
+// newReportRange returns the range between pos and end.
+// If end is not valid, returns an empty range at pos.
+func newReportRange(fset *token.FileSet, wd string, pos, end token.Pos) reportRange {
+	if !end.IsValid() {
+		end = pos
+	}
+	start, stop := fset.Position(pos), fset.Position(end)
+	return reportRange{
+		File:      relativePath(wd, start.Filename),
+		Line:      start.Line,
+		Column:    start.Column,
+		EndLine:   stop.Line,
+		EndColumn: stop.Column,
+	}
+}
+
+// collectDiagnostics collects all diagnostics, sorted by position.
+// Errors of individual analyzers are printed, and reported as failed.
+func collectDiagnostics(graph *checker.Graph) (diagnostics []reportDiagnostic, failed bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		wd = ""
+	}
+
+	seen := make(map[string]struct{})
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", act, act.Err)
+			failed = true
+			continue
+		}
+
+		fset := act.Package.Fset
+		for _, diagnostic := range act.Diagnostics {
+			report := reportDiagnostic{
+				Analyzer: act.Analyzer.Name,
+				Category: diagnostic.Category,
+				Range:    newReportRange(fset, wd, diagnostic.Pos, diagnostic.End),
+				Message:  diagnostic.Message,
+			}
+			for _, fix := range diagnostic.SuggestedFixes {
+				report.Fixes = append(report.Fixes, newReportFix(fset, wd, fix))
+			}
+
+			// test variants of a package report the same diagnostics
+			key := fmt.Sprintf("%s\x00%v\x00%s", report.Analyzer, report.Range, report.Message)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			diagnostics = append(diagnostics, report)
+		}
+	}
+
+	slices.SortStableFunc(diagnostics, func(a, b reportDiagnostic) int {
+		return cmp.Or(
+			strings.Compare(a.Range.File, b.Range.File),
+			cmp.Compare(a.Range.Line, b.Range.Line),
+			cmp.Compare(a.Range.Column, b.Range.Column),
+			strings.Compare(a.Analyzer, b.Analyzer),
+		)
+	})
+	return diagnostics, failed
+}
+
+// newReportFix converts a suggested fix for a report.
+func newReportFix(fset *token.FileSet, wd string, fix analysis.SuggestedFix) reportFix {
+	report := reportFix{Message: fix.Message, Edits: make([]reportEdit, 0, len(fix.TextEdits))}
+	for _, edit := range fix.TextEdits {
+		report.Edits = append(report.Edits, reportEdit{
+			Range:   newReportRange(fset, wd, edit.Pos, edit.End),
+			NewText: string(edit.NewText),
+		})
+	}
+	return report
+}
+
+// writeJSON writes diagnostics as a json array.
+func writeJSON(w io.Writer, diagnostics []reportDiagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []reportDiagnostic{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(diagnostics)
+}
+
+// writeSARIF writes diagnostics as a SARIF 2.1.0 log.
+func writeSARIF(w io.Writer, diagnostics []reportDiagnostic) error {
+	columns := newRuneColumns()
+
+	rules := make([]sarifRule, len(analyzers))
+	ruleIndex := make(map[string]int, len(analyzers))
+	for i, analyzer := range analyzers {
+		summary, _, _ := strings.Cut(analyzer.Doc, "\n")
+		rules[i] = sarifRule{
+			ID:               analyzer.Name,
+			ShortDescription: sarifMessage{Text: summary},
+			FullDescription:  sarifMessage{Text: analyzer.Doc},
+		}
+		ruleIndex[analyzer.Name] = i
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		result := sarifResult{
+			RuleID:    diagnostic.Analyzer,
+			RuleIndex: ruleIndex[diagnostic.Analyzer],
+			Level:     "warning",
+			Message:   sarifMessage{Text: diagnostic.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: newArtifactLocation(diagnostic.Range.File),
+					Region:           columns.region(diagnostic.Range),
+				},
+			}},
+		}
+
+		for _, fix := range diagnostic.Fixes {
+			sarifFix := sarifFix{Description: sarifMessage{Text: fix.Message}}
+			for _, edit := range fix.Edits {
+				change := sarifArtifactChange{
+					ArtifactLocation: newArtifactLocation(edit.Range.File),
+					Replacements: []sarifReplacement{{
+						DeletedRegion:   columns.region(edit.Range),
+						InsertedContent: sarifContent{Text: edit.NewText},
+					}},
+				}
+				sarifFix.ArtifactChanges = append(sarifFix.ArtifactChanges, change)
+			}
+			result.Fixes = append(result.Fixes, sarifFix)
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:        sarifTool{Driver: sarifDriver{Name: "go-check-spellchecker", Rules: rules}},
+			ColumnKind:  "unicodeCodePoints",
+			Results:     results,
+			OriginalURI: map[string]sarifArtifactLocation{"%SRCROOT%": {URI: sarifRoot()}},
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(log)
+}
+
+// sarifRoot returns the uri of the directory relative to which paths in a SARIF log are resolved.
+func sarifRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(fileURI(wd), "/") + "/"
+}
+
+// newArtifactLocation returns the location of a file.
+// Relative paths are resolved against the source root, other paths are absolute file uris.
+func newArtifactLocation(file string) sarifArtifactLocation {
+	if filepath.IsAbs(file) {
+		return sarifArtifactLocation{URI: fileURI(file)}
+	}
+	return sarifArtifactLocation{URI: (&url.URL{Path: filepath.ToSlash(file)}).String(), BaseID: "%SRCROOT%"}
+}
+
+// fileURI returns the 'file' uri of the absolute path.
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // a windows path starting with a drive letter
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// The following types represent the subset of SARIF 2.1.0 used by writeSARIF.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool        sarifTool                        `json:"tool"`
+		OriginalURI map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+		ColumnKind  string                           `json:"columnKind"`
+		Results     []sarifResult                    `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+		FullDescription  sarifMessage `json:"fullDescription"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+		Fixes     []sarifFix      `json:"fixes,omitempty"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+	sarifArtifactLocation struct {
+		URI    string `json:"uri"`
+		BaseID string `json:"uriBaseId,omitempty"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+		EndLine     int `json:"endLine"`
+		EndColumn   int `json:"endColumn"`
+	}
+	sarifFix struct {
+		Description     sarifMessage          `json:"description"`
+		ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+	}
+	sarifArtifactChange struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Replacements     []sarifReplacement    `json:"replacements"`
+	}
+	sarifReplacement struct {
+		DeletedRegion   sarifRegion  `json:"deletedRegion"`
+		InsertedContent sarifContent `json:"insertedContent"`
+	}
+	sarifContent struct {
+		Text string `json:"text"`
+	}
+)
+
+// runeColumns converts byte columns into columns counted in unicode code points, as used by SARIF.
+// Files are read at most once.
+type runeColumns struct {
+	lines map[string][]string
+}
+
+func newRuneColumns() *runeColumns {
+	return &runeColumns{lines: make(map[string][]string)}
+}
+
+// region converts r into a SARIF region.
+func (rc *runeColumns) region(r reportRange) sarifRegion {
+	return sarifRegion{
+		StartLine:   r.Line,
+		StartColumn: rc.column(r.File, r.Line, r.Column),
+		EndLine:     r.EndLine,
+		EndColumn:   rc.column(r.File, r.EndLine, r.EndColumn),
+	}
+}
+
+// column converts the byte column of the given line of file into a rune column.
+// If the file can not be read, returns column unchanged.
+func (rc *runeColumns) column(file string, line, column int) int {
+	lines, ok := rc.lines[file]
+	if !ok {
+		if data, err := os.ReadFile(file); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		rc.lines[file] = lines
+	}
+
+	if line < 1 || line > len(lines) || column < 1 || column-1 > len(lines[line-1]) {
+		return column
+	}
+	return utf8.RuneCountInString(lines[line-1][:column-1]) + 1
+}
+
+// writeCheckstyle writes diagnostics as a checkstyle XML report.
+// Suggested fixes are included in the message of each error.
+func writeCheckstyle(w io.Writer, diagnostics []reportDiagnostic) error {
+	report := checkstyleReport{Version: "5.0"}
+	for _, diagnostic := range diagnostics {
+		if len(report.Files) == 0 || report.Files[len(report.Files)-1].Name != diagnostic.Range.File {
+			report.Files = append(report.Files, checkstyleFile{Name: diagnostic.Range.File})
+		}
+		file := &report.Files[len(report.Files)-1]
+
+		message := diagnostic.Message
+		for _, fix := range diagnostic.Fixes {
+			var texts []string
+			for _, edit := range fix.Edits {
+				texts = append(texts, fmt.Sprintf("%q", edit.NewText))
+			}
+			message += fmt.Sprintf(" (suggested fix: %s: %s)", fix.Message, strings.Join(texts, ", "))
+		}
+
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     diagnostic.Range.Line,
+			Column:   diagnostic.Range.Column,
+			Severity: "warning",
+			Message:  message,
+			Source:   diagnostic.Analyzer,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+//spellchecker:words XMLName
+
+// The following types represent a checkstyle XML report.
+type (
+	checkstyleReport struct {
+		XMLName xml.Name         `xml:"checkstyle"`
+		Version string           `xml:"version,attr"`
+		Files   []checkstyleFile `xml:"file"`
+	}
+	checkstyleFile struct {
+		Name   string            `xml:"name,attr"`
+		Errors []checkstyleError `xml:"error"`
+	}
+	checkstyleError struct {
+		Line     int    `xml:"line,attr"`
+		Column   int    `xml:"column,attr"`
+		Severity string `xml:"severity,attr"`
+		Message  string `xml:"message,attr"`
+		Source   string `xml:"source,attr"`
+	}
+)
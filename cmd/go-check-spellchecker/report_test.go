@@ -0,0 +1,105 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bytes flag path filepath strings testing
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of report tests")
+
+// testDiagnostics are diagnostics in the files of testdata/report.
+var testDiagnostics = []reportDiagnostic{
+	{
+		Analyzer: "spellchecker_identifiers",
+		Category: "word",
+		Range:    reportRange{File: "testdata/report/report.go", Line: 3, Column: 23, EndLine: 3, EndColumn: 27},
+		Message:  `unknown word "qwzx"`,
+		Fixes: []reportFix{{
+			Message: "add a 'spellchecker:words' directive",
+			Edits: []reportEdit{{
+				Range:   reportRange{File: "testdata/report/report.go", Line: 3, Column: 1, EndLine: 3, EndColumn: 1},
+				NewText: "//spellchecker:words qwzx\n",
+			}},
+		}},
+	},
+	{
+		Analyzer: "spellchecker_comments",
+		Category: "word",
+		Range:    reportRange{File: "/outside/of the/root.go", Line: 1, Column: 4, EndLine: 1, EndColumn: 8},
+		Message:  `unknown word "zxqw"`,
+	},
+}
+
+func TestReporters(t *testing.T) {
+	for _, tt := range []struct {
+		format string
+		golden string
+	}{
+		{"json", "report.json"},
+		{"sarif", "report.sarif"},
+		{"checkstyle", "report.xml"},
+	} {
+		t.Run(tt.format, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := reporters[tt.format](&buffer, testDiagnostics); err != nil {
+				t.Fatalf("reporter error = %v", err)
+			}
+			// the source root depends on the working directory
+			got := strings.ReplaceAll(buffer.String(), sarifRoot(), "file:///root/")
+
+			golden := filepath.Join("testdata", "report", tt.golden)
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("report differs from %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestRuneColumns(t *testing.T) {
+	columns := newRuneColumns()
+	for _, tt := range []struct {
+		file         string
+		line, column int
+		want         int
+	}{
+		{"testdata/report/report.go", 1, 1, 1},
+		{"testdata/report/report.go", 3, 23, 20},
+		{"testdata/report/report.go", 3, 27, 24},
+		{"testdata/report/report.go", 3, 100, 100}, // beyond the end of the line
+		{"testdata/report/report.go", 100, 1, 1},   // beyond the end of the file
+		{"testdata/report/missing.go", 3, 23, 23},
+	} {
+		if got := columns.column(tt.file, tt.line, tt.column); got != tt.want {
+			t.Errorf("column(%q, %d, %d) = %d, want %d", tt.file, tt.line, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := writeReport(path, writeJSON, nil); err != nil {
+		t.Fatalf("writeReport() error = %v", err)
+	}
+	if got, err := os.ReadFile(path); err != nil || string(got) != "[]\n" {
+		t.Errorf("writeReport() wrote %q, %v, want %q", got, err, "[]\n")
+	}
+
+	if err := writeReport(filepath.Join(path, "report.json"), writeJSON, nil); err == nil {
+		t.Error("writeReport() into a file succeeded, want error")
+	}
+}
@@ -0,0 +1,4 @@
+package report
+
+// Grüße aus Köln: qwzx
+var _ = 1
@@ -0,0 +1,83 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bufio flag strings check spellchecker
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+const usage = `Usage: spellchecker-lsp [flags]
+
+Runs a language server on standard input and output that validates and
+normalizes spellchecker directives in files other than Go source files.
+
+Directives are found in the comments of each file, using the comment syntax of
+its language. The comment syntax of a language is either a line comment prefix
+such as '#', the delimiters of block comments such as '<!-- -->', or both.
+
+Flags:
+`
+
+func main() {
+	syntaxes := defaultSyntaxes()
+
+	flag.Func("comment", "set the comment syntax of a language, in the form 'language=syntax', e.g. 'yaml=#', 'html=<!-- -->' or 'sql=-- /* */' (may be repeated)", func(value string) error {
+		language, text, ok := strings.Cut(value, "=")
+		if !ok || language == "" {
+			return fmt.Errorf("expected 'language=syntax', got %q", value)
+		}
+		syntax, err := spellchecker.ParseCommentSyntax(text)
+		if err != nil {
+			return err
+		}
+		syntaxes[language] = syntax
+		return nil
+	})
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	server := newServer(syntaxes, os.Stdout)
+	os.Exit(server.Serve(bufio.NewReader(os.Stdin)))
+}
+
+// defaultSyntaxes returns the comment syntax of common languages, indexed by their language identifier.
+func defaultSyntaxes() map[string]spellchecker.CommentSyntax {
+	var (
+		hash  = spellchecker.CommentSyntax{Line: "#"}
+		dash  = spellchecker.CommentSyntax{Line: "--"}
+		xml   = spellchecker.CommentSyntax{BlockStart: "<!--", BlockEnd: "-->"}
+		slash = spellchecker.CommentSyntax{Line: "//", BlockStart: "/*", BlockEnd: "*/"}
+	)
+	return map[string]spellchecker.CommentSyntax{
+		"yaml":        hash,
+		"shellscript": hash,
+		"python":      hash,
+		"toml":        hash,
+		"dockerfile":  hash,
+		"makefile":    hash,
+		"ruby":        hash,
+		"perl":        hash,
+		"sql":         {Line: "--", BlockStart: "/*", BlockEnd: "*/"},
+		"lua":         dash,
+		"haskell":     dash,
+		"markdown":    xml,
+		"html":        xml,
+		"xml":         xml,
+		"css":         {BlockStart: "/*", BlockEnd: "*/"},
+		"javascript":  slash,
+		"typescript":  slash,
+		"c":           slash,
+		"cpp":         slash,
+		"java":        slash,
+		"rust":        slash,
+	}
+}
@@ -0,0 +1,219 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bufio encoding json errors strconv strings unicode
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// message is a JSON-RPC 2.0 request, notification or response.
+type message struct {
+	Version string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+// responseError is the error of a JSON-RPC response.
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes.
+const (
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+)
+
+var (
+	errNoContentLength = errors.New("missing Content-Length header")
+	errInvalidMessage  = errors.New("invalid message")
+)
+
+// readMessage reads a single message, preceded by its headers, from r.
+// If the body of the message is not a valid message, returns an error wrapping errInvalidMessage.
+// Any other error means that no further messages can be read.
+func readMessage(r *bufio.Reader) (*message, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errNoContentLength
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidMessage, err)
+	}
+	return &msg, nil
+}
+
+// writeMessage writes msg, preceded by its headers, to w.
+func writeMessage(w io.Writer, msg *message) error {
+	msg.Version = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// position is a position in a text document, see the language server protocol.
+// Characters are counted in UTF-16 code units.
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// lspRange is a range in a text document.
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+// newPosition returns the position of the byte offset in text.
+func newPosition(text string, offset int) position {
+	var pos position
+	for _, r := range text[:offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Character = 0
+			continue
+		}
+		pos.Character += len(utf16.Encode([]rune{r}))
+	}
+	return pos
+}
+
+// newRange returns the range between the byte offsets start and end in text.
+func newRange(text string, start, end int) lspRange {
+	return lspRange{Start: newPosition(text, start), End: newPosition(text, end)}
+}
+
+// offset returns the byte offset of pos in text.
+// Positions beyond the end of a line or the text are clamped.
+func (pos position) offset(text string) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		index := strings.IndexByte(text[offset:], '\n')
+		if index < 0 {
+			return len(text)
+		}
+		offset += index + 1
+	}
+
+	for character := 0; character < pos.Character && offset < len(text) && text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		character += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+	return offset
+}
+
+// textDocumentItem is a text document transferred from the client.
+type textDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Text       string `json:"text"`
+}
+
+// textDocumentIdentifier identifies a text document.
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// didOpenParams are the parameters of the 'textDocument/didOpen' notification.
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+// didChangeParams are the parameters of the 'textDocument/didChange' notification.
+// As the server only supports full synchronization, each change contains the entire text.
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+// didCloseParams are the parameters of the 'textDocument/didClose' notification.
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+// codeActionParams are the parameters of the 'textDocument/codeAction' request.
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Range        lspRange               `json:"range"`
+}
+
+// diagnostic is a diagnostic published to the client.
+type diagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+// severityWarning is the severity of all diagnostics.
+const severityWarning = 2
+
+// publishDiagnosticsParams are the parameters of the 'textDocument/publishDiagnostics' notification.
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+// textEdit is an edit of a text document.
+type textEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+// codeAction is a quick fix offered to the client.
+type codeAction struct {
+	Title       string       `json:"title"`
+	Kind        string       `json:"kind"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+	IsPreferred bool         `json:"isPreferred"`
+	Edit        struct {
+		Changes map[string][]textEdit `json:"changes"`
+	} `json:"edit"`
+}
@@ -0,0 +1,110 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bufio bytes encoding json errors strings testing
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadMessage(t *testing.T) {
+	var buffer bytes.Buffer
+	id := json.RawMessage(`1`)
+	for _, msg := range []*message{
+		{ID: &id, Method: "initialize", Params: json.RawMessage(`{}`)},
+		{Method: "textDocument/didOpen", Params: json.RawMessage(`{"textDocument":{"uri":"file:///a.yaml","text":"ä\r\n"}}`)},
+	} {
+		if err := writeMessage(&buffer, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// headers other than Content-Length are ignored, and names are compared under case folding
+	buffer.WriteString("Content-Type: application/vscode-jsonrpc; charset=utf-8\r\ncontent-length: 16\r\n\r\n" + `{"method":"exit"}`[:16])
+
+	r := bufio.NewReader(&buffer)
+	for _, want := range []string{"initialize", "textDocument/didOpen"} {
+		msg, err := readMessage(r)
+		if err != nil {
+			t.Fatalf("readMessage() error = %v", err)
+		}
+		if msg.Version != "2.0" || msg.Method != want {
+			t.Errorf("readMessage() = %q %q, want %q %q", msg.Version, msg.Method, "2.0", want)
+		}
+	}
+	if _, err := readMessage(r); !errors.Is(err, errInvalidMessage) {
+		t.Errorf("readMessage() of truncated body error = %v, want %v", err, errInvalidMessage)
+	}
+	if _, err := readMessage(r); !errors.Is(err, io.EOF) {
+		t.Errorf("readMessage() at end error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestReadMessage_invalid(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"missing length", "Content-Type: text/plain\r\n\r\n{}", errNoContentLength},
+		{"short body", "Content-Length: 10\r\n\r\n{}", io.ErrUnexpectedEOF},
+		{"invalid json", "Content-Length: 2\r\n\r\n{]", errInvalidMessage},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := readMessage(bufio.NewReader(strings.NewReader(tt.input))); !errors.Is(err, tt.want) {
+				t.Errorf("readMessage() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := readMessage(bufio.NewReader(strings.NewReader("Content-Length: ten\r\n\r\n"))); err == nil {
+		t.Error("readMessage() with invalid Content-Length succeeded, want error")
+	}
+}
+
+func TestPosition(t *testing.T) {
+	// 'ä' is two bytes and one UTF-16 code unit, '😀' is four bytes and two UTF-16 code units
+	text := "ab\nä😀c\n\nend"
+	for _, tt := range []struct {
+		offset int
+		want   position
+	}{
+		{0, position{0, 0}},
+		{2, position{0, 2}},
+		{3, position{1, 0}},
+		{5, position{1, 1}},
+		{9, position{1, 3}},
+		{10, position{1, 4}},
+		{11, position{2, 0}},
+		{12, position{3, 0}},
+		{len(text), position{3, 3}},
+	} {
+		if got := newPosition(text, tt.offset); got != tt.want {
+			t.Errorf("newPosition(%d) = %v, want %v", tt.offset, got, tt.want)
+		}
+		if got := tt.want.offset(text); got != tt.offset {
+			t.Errorf("%v.offset() = %d, want %d", tt.want, got, tt.offset)
+		}
+	}
+}
+
+func TestPosition_offsetClamped(t *testing.T) {
+	text := "ab\nä😀c\n\nend"
+	for _, tt := range []struct {
+		pos  position
+		want int
+	}{
+		{position{0, 10}, 2},         // beyond the end of a line
+		{position{1, 2}, 9},          // within a surrogate pair
+		{position{2, 5}, 11},         // on an empty line
+		{position{3, 10}, len(text)}, // beyond the end of the last line
+		{position{10, 0}, len(text)}, // beyond the last line
+	} {
+		if got := tt.pos.offset(text); got != tt.want {
+			t.Errorf("%v.offset() = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+}
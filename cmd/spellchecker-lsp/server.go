@@ -0,0 +1,259 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bufio encoding json errors path filepath check spellchecker
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+// server is a language server validating the directives of open documents.
+type server struct {
+	syntaxes  map[string]spellchecker.CommentSyntax // comment syntax by language identifier
+	out       io.Writer                             // output to the client
+	documents map[string]*document                  // open documents by uri
+	shutdown  bool                                  // was a shutdown requested?
+}
+
+// document is an open text document.
+type document struct {
+	uri         string
+	text        string
+	syntax      spellchecker.CommentSyntax
+	supported   bool // is the language of the document supported?
+	diagnostics []spellchecker.TextDiagnostic
+}
+
+func newServer(syntaxes map[string]spellchecker.CommentSyntax, out io.Writer) *server {
+	return &server{syntaxes: syntaxes, out: out, documents: make(map[string]*document)}
+}
+
+// Serve reads messages from r and handles them until the client exits.
+// Returns the exit code of the server.
+func (s *server) Serve(r *bufio.Reader) int {
+	for {
+		msg, err := readMessage(r)
+		if errors.Is(err, errInvalidMessage) {
+			fmt.Fprintln(os.Stderr, err)
+			continue // the next message can still be read
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return 1
+		}
+
+		if msg.Method == "exit" {
+			if s.shutdown {
+				return 0
+			}
+			return 1
+		}
+
+		if err := s.handle(msg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+}
+
+// handle handles a single request or notification.
+// Invalid parameters are answered with an error, or logged for notifications.
+// The returned error is an error writing to the client.
+func (s *server) handle(msg *message) error {
+	var (
+		result any
+		rErr   *responseError
+	)
+
+	switch msg.Method {
+	case "initialize":
+		result = map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   map[string]any{"openClose": true, "change": 1},
+				"codeActionProvider": map[string]any{"codeActionKinds": []string{"quickfix"}},
+			},
+			"serverInfo": map[string]any{"name": "spellchecker-lsp"},
+		}
+
+	case "shutdown":
+		s.shutdown = true
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.invalidParams(msg, err)
+		}
+		syntax, supported := s.syntaxes[params.TextDocument.LanguageID]
+		doc := &document{uri: params.TextDocument.URI, text: params.TextDocument.Text, syntax: syntax, supported: supported}
+		s.documents[doc.uri] = doc
+		return s.check(doc)
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.invalidParams(msg, err)
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok || len(params.ContentChanges) == 0 {
+			return nil
+		}
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return s.check(doc)
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.invalidParams(msg, err)
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.invalidParams(msg, err)
+		}
+		result = s.codeActions(params)
+
+	default:
+		if msg.ID == nil {
+			return nil // ignore unknown notifications
+		}
+		rErr = &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", msg.Method)}
+	}
+
+	if msg.ID == nil {
+		return nil
+	}
+	if rErr != nil {
+		return writeMessage(s.out, &message{ID: msg.ID, Error: rErr})
+	}
+
+	// successful responses always have a result, even if it is null
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{ID: msg.ID, Result: data})
+}
+
+// invalidParams handles msg having invalid parameters.
+// Requests are answered with an error, notifications can not be answered and are logged instead.
+func (s *server) invalidParams(msg *message, err error) error {
+	if msg.ID == nil {
+		fmt.Fprintf(os.Stderr, "invalid parameters of %q: %s\n", msg.Method, err)
+		return nil
+	}
+	return writeMessage(s.out, &message{ID: msg.ID, Error: &responseError{Code: codeInvalidParams, Message: err.Error()}})
+}
+
+// notify sends a notification to the client.
+func (s *server) notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: data})
+}
+
+// check checks the directives of doc, and publishes the diagnostics.
+func (s *server) check(doc *document) error {
+	doc.diagnostics = nil
+	if doc.supported {
+		cfg, err := loadConfig(doc.uri)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			cfg = spellchecker.DefaultConfig()
+		}
+		doc.diagnostics = cfg.CheckText(doc.text, doc.syntax)
+	}
+
+	diagnostics := make([]diagnostic, len(doc.diagnostics))
+	for i, d := range doc.diagnostics {
+		diagnostics[i] = doc.diagnostic(d)
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: diagnostics})
+}
+
+// loadConfig loads the configuration for the document with the given uri.
+// Documents that are not files use the default configuration.
+func loadConfig(uri string) (*spellchecker.Config, error) {
+	path, ok := filePath(uri)
+	if !ok {
+		return spellchecker.DefaultConfig(), nil
+	}
+	return spellchecker.LoadConfig(filepath.Dir(path))
+}
+
+// filePath returns the path of the file with the given uri, and if the uri refers to a file.
+// Windows paths, such as in 'file:///C:/dir/file', do not keep the leading slash before the drive letter.
+func filePath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+
+	path := u.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' && isDriveLetter(path[1]) {
+		path = path[1:]
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		path = "//" + u.Host + path // a windows network share
+	}
+	return filepath.FromSlash(path), true
+}
+
+// isDriveLetter checks if c is the letter of a windows drive.
+func isDriveLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// diagnostic converts d into a diagnostic of the protocol.
+func (doc *document) diagnostic(d spellchecker.TextDiagnostic) diagnostic {
+	return diagnostic{
+		Range:    newRange(doc.text, d.Start, d.End),
+		Severity: severityWarning,
+		Code:     d.Category,
+		Source:   "spellchecker",
+		Message:  d.Message,
+	}
+}
+
+// codeActions returns quick fixes for the diagnostics of a document overlapping the requested range.
+func (s *server) codeActions(params codeActionParams) []codeAction {
+	actions := []codeAction{}
+
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return actions
+	}
+
+	start, end := params.Range.Start.offset(doc.text), params.Range.End.offset(doc.text)
+	for _, d := range doc.diagnostics {
+		if d.Fix == nil || d.End < start || end < d.Start {
+			continue
+		}
+
+		action := codeAction{
+			Title:       d.Fix.Message,
+			Kind:        "quickfix",
+			Diagnostics: []diagnostic{doc.diagnostic(d)},
+			IsPreferred: true,
+		}
+		action.Edit.Changes = map[string][]textEdit{
+			doc.uri: {{Range: newRange(doc.text, d.Fix.Start, d.Fix.End), NewText: d.Fix.NewText}},
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
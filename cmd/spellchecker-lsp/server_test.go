@@ -0,0 +1,108 @@
+//spellchecker:words main
+package main
+
+//spellchecker:words bufio bytes encoding json path filepath strconv strings testing
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	var input bytes.Buffer
+	send := func(id int, method string, params string) {
+		t.Helper()
+
+		msg := &message{Method: method, Params: json.RawMessage(params)}
+		if id > 0 {
+			raw := json.RawMessage(strconv.Itoa(id))
+			msg.ID = &raw
+		}
+		if err := writeMessage(&input, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const uri = "untitled:test.yaml"
+	send(1, "initialize", `{}`)
+	send(0, "textDocument/didOpen", `{"textDocument":"not an object"}`)
+	send(0, "textDocument/didOpen", `{"textDocument":{"uri":"`+uri+`","languageId":"yaml","text":"# spellchecker:words   alpha\n"}}`)
+	send(2, "textDocument/codeAction", `{"textDocument":[]}`)
+	send(3, "textDocument/codeAction", `{"textDocument":{"uri":"`+uri+`"},"range":{"start":{"line":0,"character":2},"end":{"line":0,"character":2}}}`)
+	input.WriteString("Content-Length: 5\r\n\r\nnull}")
+	send(4, "unknown/method", `{}`)
+	send(0, "textDocument/didClose", `{"textDocument":{"uri":"`+uri+`"}}`)
+	send(5, "shutdown", `null`)
+	send(0, "exit", `null`)
+
+	var output bytes.Buffer
+	if code := newServer(defaultSyntaxes(), &output).Serve(bufio.NewReader(&input)); code != 0 {
+		t.Errorf("Serve() = %d, want 0", code)
+	}
+
+	var got []string
+	r := bufio.NewReader(&output)
+	for output.Len() > 0 || r.Buffered() > 0 {
+		msg, err := readMessage(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Error != nil && msg.Error.Code == codeInvalidParams {
+			msg.Error.Message = "" // depends on encoding/json
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(data))
+	}
+
+	want := []string{
+		`{"jsonrpc":"2.0","id":1,"result":{"capabilities":{"codeActionProvider":{"codeActionKinds":["quickfix"]},"textDocumentSync":{"change":1,"openClose":true}},"serverInfo":{"name":"spellchecker-lsp"}}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"untitled:test.yaml","diagnostics":[{"range":{"start":{"line":0,"character":2},"end":{"line":0,"character":28}},"severity":2,"code":"directive","source":"spellchecker","message":"improperly formatted 'words' directive"}]}}`,
+		`{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":""}}`,
+		`{"jsonrpc":"2.0","id":3,"result":[{"title":"reformat 'words' directive","kind":"quickfix","diagnostics":[{"range":{"start":{"line":0,"character":2},"end":{"line":0,"character":28}},"severity":2,"code":"directive","source":"spellchecker","message":"improperly formatted 'words' directive"}],"isPreferred":true,"edit":{"changes":{"untitled:test.yaml":[{"range":{"start":{"line":0,"character":2},"end":{"line":0,"character":28}},"newText":"spellchecker:words alpha"}]}}}]}`,
+		`{"jsonrpc":"2.0","id":4,"error":{"code":-32601,"message":"method \"unknown/method\" not found"}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"untitled:test.yaml","diagnostics":[]}}`,
+		`{"jsonrpc":"2.0","id":5,"result":null}`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Serve() wrote\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestServer_exitWithoutShutdown(t *testing.T) {
+	var input, output bytes.Buffer
+	if err := writeMessage(&input, &message{Method: "exit"}); err != nil {
+		t.Fatal(err)
+	}
+	if code := newServer(defaultSyntaxes(), &output).Serve(bufio.NewReader(&input)); code != 1 {
+		t.Errorf("Serve() = %d, want 1", code)
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	for _, tt := range []struct {
+		uri    string
+		want   string
+		wantOK bool
+	}{
+		{"file:///home/user/file.yaml", "/home/user/file.yaml", true},
+		{"file:///home/user/with%20space.yaml", "/home/user/with space.yaml", true},
+		{"file:///C:/Users/file.yaml", "C:/Users/file.yaml", true},
+		{"file:///c%3A/Users/file.yaml", "c:/Users/file.yaml", true},
+		{"file://localhost/home/file.yaml", "/home/file.yaml", true},
+		{"file://server/share/file.yaml", "//server/share/file.yaml", true},
+		{"untitled:Untitled-1", "", false},
+		{"https://example.com/file.yaml", "", false},
+	} {
+		got, ok := filePath(tt.uri)
+		if ok != tt.wantOK || got != filepath.FromSlash(tt.want) {
+			t.Errorf("filePath(%q) = %q, %t, want %q, %t", tt.uri, got, ok, filepath.FromSlash(tt.want), tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words bytes encoding json errors path filepath regexp slices strings sync time golang tools analysis gopkg yaml
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+//spellchecker:words HTTPServer
+
+// ConfigFiles are the names of configuration files, in order of preference.
+var ConfigFiles = []string{".spellchecker.yaml", ".spellchecker.yml", ".spellchecker.json"}
+
+// Config holds the configuration of the spellchecker analyzers.
+//
+// It is typically read from a configuration file, see [LoadConfig].
+// Fields not set in the configuration file retain their default values, see [DefaultConfig].
+type Config struct {
+	// MinWordLength is the minimal length of words.
+	// Shorter words are never added to directives, and never reported as misspelled.
+	MinWordLength int `json:"minWordLength" yaml:"minWordLength"`
+
+	// Keywords are the keywords introducing a directive, compared under case folding.
+	// The 'spellchecker' keyword is always recognized.
+	Keywords []string `json:"keywords" yaml:"keywords"`
+
+	// ExcludeWords are words never added to directives, and never reported as misspelled.
+	// They are compared under case folding.
+	ExcludeWords []string `json:"excludeWords" yaml:"excludeWords"`
+
+	// PackageExcludeWords are words never added to the directive of a package clause.
+	PackageExcludeWords []string `json:"packageExcludeWords" yaml:"packageExcludeWords"`
+
+	// Generated is a regular expression matching the first comment of generated files.
+	// Generated files are never checked.
+	Generated string `json:"generated" yaml:"generated"`
+
+	// Dictionaries are paths to additional dictionaries, see [ReadDictionary].
+	// Relative paths are resolved relative to the directory of the configuration file.
+	//
+	// Words known to these dictionaries are not reported as misspelled.
+	// If any dictionary is configured, they are also omitted from 'spellchecker:words' directives of package clauses and imports.
+	// To omit words from the built-in dictionary, include [BuiltinDictionary].
+	Dictionaries []string `json:"dictionaries" yaml:"dictionaries"`
+
+	// ConvertBlockDirectives enables converting block comments ('/* */') that only contain directives into line comments ('//').
+	// Comments are only converted if nothing else is on the same lines.
+	ConvertBlockDirectives bool `json:"convertBlockDirectives" yaml:"convertBlockDirectives"`
+
+	// DeclarationDirectives enables managed 'spellchecker:words' directives for each top-level func, type, const and var declaration.
+	// These contain the words of identifiers defined in the declaration that are not known to any dictionary.
+	DeclarationDirectives bool `json:"declarationDirectives" yaml:"declarationDirectives"`
+
+	// SortWords enables sorting the words of 'spellchecker:words' directives, compared under case folding.
+	// Otherwise words retain the order they occur in.
+	SortWords bool `json:"sortWords" yaml:"sortWords"`
+
+	// MaxLineLength is the maximal length of lines containing managed 'spellchecker:words' directives.
+	// Longer directives are wrapped into multiple consecutive directives.
+	// A value of 0 disables wrapping.
+	MaxLineLength int `json:"maxLineLength" yaml:"maxLineLength"`
+
+	// StructTags enables the words of struct tag keys and values, such as 'json:"userUUID"'.
+	// Unknown words of struct tags are added to the managed 'spellchecker:words' directives of declarations, see DeclarationDirectives and [LayoutFile],
+	// and are otherwise reported as misspelled.
+	StructTags bool `json:"structTags" yaml:"structTags"`
+
+	// Acronyms enables splitting identifiers and prose at the end of acronyms, so that "HTTPServer" consists of the words "HTTP" and "Server".
+	// See [SplitOptions].
+	Acronyms bool `json:"acronyms" yaml:"acronyms"`
+
+	// Initialisms are known initialisms, such as "ID" or "URL", that are split off as words of their own.
+	// They only take effect if Acronyms is set, see [SplitOptions].
+	Initialisms []string `json:"initialisms" yaml:"initialisms"`
+
+	// Layout determines where managed 'spellchecker:words' directives are placed, see [LayoutSplit] and [LayoutFile].
+	Layout string `json:"layout" yaml:"layout"`
+
+	dir       string         // directory of the configuration file
+	generated *regexp.Regexp // compiled version of Generated
+	dict      Dictionary     // words from all Dictionaries, or nil
+	dictNames Dictionary     // names of dictionaries that may be referenced by a 'dictionaries' directive
+}
+
+// Layouts of managed 'spellchecker:words' directives.
+const (
+	// LayoutSplit places one directive before the package clause and one before each import declaration.
+	LayoutSplit = "split"
+
+	// LayoutFile places a single directive before the package clause, containing the words of the package name, all imports and the unknown words of all identifiers.
+	LayoutFile = "file"
+)
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	cfg := &Config{
+		MinWordLength:       4,
+		Keywords:            slices.Clone(defaultKeywords),
+		PackageExcludeWords: []string{"test"},
+		Generated:           `^// Code generated .* DO NOT EDIT\.$`,
+		Layout:              LayoutSplit,
+	}
+	if err := cfg.init(); err != nil {
+		panic("DefaultConfig: invalid default configuration")
+	}
+	return cfg
+}
+
+// init validates the configuration and initializes derived values.
+func (cfg *Config) init() (err error) {
+	if cfg.MinWordLength < 0 {
+		return fmt.Errorf("invalid minWordLength %d: must not be negative", cfg.MinWordLength)
+	}
+
+	if cfg.MaxLineLength < 0 {
+		return fmt.Errorf("invalid maxLineLength %d: must not be negative", cfg.MaxLineLength)
+	}
+
+	if cfg.Layout != LayoutSplit && cfg.Layout != LayoutFile {
+		return fmt.Errorf("invalid layout %q: must be %q or %q", cfg.Layout, LayoutSplit, LayoutFile)
+	}
+	if cfg.Layout == LayoutFile && cfg.DeclarationDirectives {
+		return fmt.Errorf("declarationDirectives can not be used with layout %q", LayoutFile)
+	}
+
+	if !slices.ContainsFunc(cfg.Keywords, func(keyword string) bool { return strings.EqualFold(keyword, correctKeyword) }) {
+		cfg.Keywords = append(slices.Clip(cfg.Keywords), correctKeyword)
+	}
+
+	cfg.generated, err = regexp.Compile(cfg.Generated)
+	if err != nil {
+		return fmt.Errorf("invalid generated pattern: %w", err)
+	}
+
+	cfg.dict = nil
+	cfg.dictNames = NewDictionary(cspellDictionaries...)
+	if len(cfg.Dictionaries) > 0 {
+		cfg.dict = make(Dictionary)
+		for _, path := range cfg.Dictionaries {
+			words, err := loadDictionary(cfg.resolve(path))
+			if err != nil {
+				return fmt.Errorf("failed to load dictionary: %w", err)
+			}
+			cfg.dict.AddDictionary(words)
+
+			names, err := dictionaryNames(cfg.resolve(path))
+			if err != nil {
+				return fmt.Errorf("failed to load dictionary: %w", err)
+			}
+			cfg.dictNames.Add(names...)
+		}
+	}
+	return nil
+}
+
+// resolve resolves a path relative to the directory of the configuration file.
+func (cfg *Config) resolve(path string) string {
+	if path == BuiltinDictionary || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cfg.dir, path)
+}
+
+// splitOptions returns the options for splitting text into words.
+func (cfg *Config) splitOptions() SplitOptions {
+	return SplitOptions{Acronyms: cfg.Acronyms, Initialisms: cfg.Initialisms}
+}
+
+// splitWords splits text into words according to the configuration.
+func (cfg *Config) splitWords(text string) []string {
+	return SplitWordsWith(text, cfg.splitOptions())
+}
+
+// isDictionary checks if name refers to a known dictionary.
+// Known dictionaries are those bundled with cspell, as well as the configured dictionaries and dictionaries defined in them.
+func (cfg *Config) isDictionary(name string) bool {
+	_, ok := cfg.dictNames[strings.ToLower(name)]
+	return ok
+}
+
+// isDirectiveWord checks if word should be added to a managed 'spellchecker:words' directive.
+// This excludes short words, excluded words and words in any configured dictionary.
+func (cfg *Config) isDirectiveWord(word string) bool {
+	return len(word) >= cfg.MinWordLength && !cfg.isExcluded(word) && (cfg.dict == nil || !cfg.dict.Contains(word))
+}
+
+// canonicalWords returns the canonical form of a list of words for a 'spellchecker:words' directive.
+// Duplicate words, compared under case folding, are removed, and words are sorted if enabled.
+func (cfg *Config) canonicalWords(words []string) []string {
+	words = deduplicateWords(slices.Clone(words))
+	if cfg.SortWords {
+		slices.SortStableFunc(words, func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+	}
+	return words
+}
+
+// formatWordsDirective formats a single 'spellchecker:words' directive listing the canonical form of words.
+func (cfg *Config) formatWordsDirective(words []string) string {
+	return FormatDirective("words", strings.Join(cfg.canonicalWords(words), " "))
+}
+
+// wrapWordsDirective is like formatWordsDirective, but wraps the directive into multiple directives to respect MaxLineLength.
+// Each directive contains at least one word.
+func (cfg *Config) wrapWordsDirective(words []string) []string {
+	words = cfg.canonicalWords(words)
+	if cfg.MaxLineLength == 0 {
+		return []string{FormatDirective("words", strings.Join(words, " "))}
+	}
+
+	var (
+		lines  []string
+		line   []string
+		prefix = len("//" + FormatDirective("words", ""))
+		length = prefix
+	)
+	for _, word := range words {
+		if len(line) > 0 && length+len(" ")+len(word) > cfg.MaxLineLength {
+			lines = append(lines, FormatDirective("words", strings.Join(line, " ")))
+			line, length = nil, prefix
+		}
+		line = append(line, word)
+		length += len(" ") + len(word)
+	}
+	return append(lines, FormatDirective("words", strings.Join(line, " ")))
+}
+
+// isUnknownWord checks if word should be added to a managed 'spellchecker:words' directive of a declaration.
+// This excludes short words, excluded words and words in the built-in or any configured dictionary.
+func (cfg *Config) isUnknownWord(word string) bool {
+	return len(word) >= cfg.MinWordLength && !cfg.isExcluded(word) && !cfg.isKnownWord(word)
+}
+
+// isKnownWord checks if word, or a simple inflection of it, is contained in the built-in dictionary or any configured dictionary.
+func (cfg *Config) isKnownWord(word string) bool {
+	return defaultDictionary().Contains(word) || (cfg.dict != nil && cfg.dict.Contains(word))
+}
+
+// isExcluded checks if word is excluded by the ExcludeWords setting.
+func (cfg *Config) isExcluded(word string) bool {
+	return slices.ContainsFunc(cfg.ExcludeWords, func(exclude string) bool { return strings.EqualFold(word, exclude) })
+}
+
+// Parse parses text into a directive using the keywords of this configuration.
+// See [CommentText.Parse].
+func (cfg *Config) Parse(text string) (ct CommentText, ok bool) {
+	ok = ct.ParseKeywords(text, cfg.Keywords)
+	return
+}
+
+// ReadConfig reads the configuration file at path.
+// Files with a '.json' extension are decoded as json, all other files as yaml.
+// Unknown settings are an error.
+func ReadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	cfg.dir = filepath.Dir(path)
+	if filepath.Ext(path) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(cfg); errors.Is(err, io.EOF) {
+			err = nil // empty file
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := cfg.init(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// FindConfig finds the configuration file applying to the given directory.
+// It checks dir and each of its parents for one of [ConfigFiles].
+//
+// If no configuration file exists, returns the empty string and no error.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range ConfigFiles {
+			path := filepath.Join(dir, name)
+			_, err := os.Stat(path)
+			if err == nil {
+				return path, nil
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig loads the configuration applying to the given directory.
+// If there is no configuration file, returns the default configuration.
+//
+// The returned configuration is cached until the configuration file changes, and must not be modified.
+// Changes to dictionaries only take effect once the configuration file referencing them is read again.
+func LoadConfig(dir string) (*Config, error) {
+	path, err := FindConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return defaultConfig(), nil
+	}
+	return configCache.load(path, ReadConfig)
+}
+
+var (
+	defaultConfig = sync.OnceValue(DefaultConfig)
+
+	configCache     fileCache[*Config]
+	dictionaryCache fileCache[Dictionary]
+)
+
+// loadDictionary is like ReadDictionary, but caches the returned dictionary until the file changes.
+// The returned dictionary must not be modified.
+func loadDictionary(path string) (Dictionary, error) {
+	if path == BuiltinDictionary {
+		return defaultDictionary(), nil
+	}
+	return dictionaryCache.load(path, ReadDictionary)
+}
+
+// fileCache caches values read from files.
+// Values are read again when the modification time or size of their file changes.
+type fileCache[T any] struct {
+	mutex   sync.Mutex
+	entries map[string]fileCacheEntry[T]
+}
+
+// fileCacheEntry is a value in a fileCache, along with the state of the file it was read from.
+type fileCacheEntry[T any] struct {
+	modTime time.Time
+	size    int64
+	value   T
+}
+
+// load returns the cached value for the file at path, calling read if there is no value or the file has changed.
+func (cache *fileCache[T]) load(path string, read func(path string) (T, error)) (value T, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return value, err
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if entry, ok := cache.entries[path]; ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.value, nil
+	}
+
+	value, err = read(path)
+	if err != nil {
+		return value, err
+	}
+
+	if cache.entries == nil {
+		cache.entries = make(map[string]fileCacheEntry[T])
+	}
+	cache.entries[path] = fileCacheEntry[T]{modTime: info.ModTime(), size: info.Size(), value: value}
+	return value, nil
+}
+
+// passConfig loads the configuration for the package analyzed by pass.
+// Flags of the analyzer override the settings of the configuration file.
+func passConfig(pass *analysis.Pass) (*Config, error) {
+	cfg := defaultConfig()
+	if len(pass.Files) > 0 {
+		// use the directory of the first file of the package
+		name := pass.Fset.Position(pass.Files[0].Package).Filename
+
+		var err error
+		cfg, err = LoadConfig(filepath.Dir(name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return overrideConfig(cfg, &pass.Analyzer.Flags)
+}
@@ -0,0 +1,82 @@
+//spellchecker:words spellchecker
+package spellchecker_test
+
+//spellchecker:words path filepath reflect testing check spellchecker
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+func TestLoadConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := "minWordLength: 3\nkeywords: [cspell]\n"
+	if err := os.WriteFile(filepath.Join(root, ".spellchecker.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := spellchecker.LoadConfig(nested)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.MinWordLength != 3 {
+		t.Errorf("LoadConfig() MinWordLength = %d, want 3", cfg.MinWordLength)
+	}
+	if want := []string{"cspell", "spellchecker"}; !reflect.DeepEqual(cfg.Keywords, want) {
+		t.Errorf("LoadConfig() Keywords = %v, want %v", cfg.Keywords, want)
+	}
+	if want := []string{"test"}; !reflect.DeepEqual(cfg.PackageExcludeWords, want) {
+		t.Errorf("LoadConfig() PackageExcludeWords = %v, want %v", cfg.PackageExcludeWords, want)
+	}
+
+	// unchanged configuration is cached
+	if again, err := spellchecker.LoadConfig(nested); err != nil || again != cfg {
+		t.Errorf("LoadConfig() = %p, %v, want cached configuration %p", again, err, cfg)
+	}
+
+	// changed configuration is read again
+	if err := os.WriteFile(filepath.Join(root, ".spellchecker.yaml"), []byte("minWordLength: 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = spellchecker.LoadConfig(nested)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.MinWordLength != 10 {
+		t.Errorf("LoadConfig() after change MinWordLength = %d, want 10", cfg.MinWordLength)
+	}
+}
+
+func TestReadConfig_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		config string
+	}{
+		{name: "invalid pattern", file: ".spellchecker.json", config: `{"generated": "("}`},
+		{name: "invalid layout", file: ".spellchecker.json", config: `{"layout": "scattered"}`},
+		{name: "file layout with declaration directives", file: ".spellchecker.json", config: `{"layout": "file", "declarationDirectives": true}`},
+		{name: "unknown json setting", file: ".spellchecker.json", config: `{"minWordLenght": 3}`},
+		{name: "unknown yaml setting", file: ".spellchecker.yaml", config: "minWordLenght: 3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := spellchecker.ReadConfig(path); err == nil {
+				t.Error("ReadConfig() did not return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words bytes encoding json errors path filepath
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CSpellConfig represents a cspell configuration file, such as 'cspell.json'.
+//
+// Only the fields used by this package are decoded.
+// Other fields are retained as is, and written back in their original order.
+// Changes to DictionaryDefinitions are not written back.
+//
+// Like cspell, comments and trailing commas are accepted (JSONC).
+// As they can not be retained, configurations containing comments can not be written back.
+type CSpellConfig struct {
+	Words                 []string                     `json:"words,omitempty"`
+	IgnoreWords           []string                     `json:"ignoreWords,omitempty"`
+	DictionaryDefinitions []CSpellDictionaryDefinition `json:"dictionaryDefinitions,omitempty"`
+
+	keys     []string                   // keys in the order they occurred in
+	fields   map[string]json.RawMessage // raw values of all keys
+	comments bool                       // did the configuration contain comments?
+}
+
+// CSpellDictionaryDefinition is a custom dictionary defined in a cspell configuration.
+type CSpellDictionaryDefinition struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// cspellField is a known field of a cspell configuration.
+type cspellField struct {
+	key      string // json key of the field
+	value    any    // pointer to the value of the field
+	readOnly bool   // is the field written back?
+}
+
+// cspellFields returns the known fields of this configuration.
+func (cs *CSpellConfig) cspellFields() []cspellField {
+	return []cspellField{
+		{"words", &cs.Words, false},
+		{"ignoreWords", &cs.IgnoreWords, false},
+		{"dictionaryDefinitions", &cs.DictionaryDefinitions, true},
+	}
+}
+
+// NewCSpellConfig returns a new cspell configuration with only the version set.
+func NewCSpellConfig() *CSpellConfig {
+	return &CSpellConfig{
+		keys:   []string{"version"},
+		fields: map[string]json.RawMessage{"version": json.RawMessage(`"0.2"`)},
+	}
+}
+
+// ReadCSpellConfig reads a cspell configuration file in json format, optionally with comments.
+func ReadCSpellConfig(path string) (*CSpellConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs CSpellConfig
+	if err := cs.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cs, nil
+}
+
+// WriteFile writes this configuration to the given path.
+// If the configuration was read from a file containing comments, returns an error instead.
+func (cs *CSpellConfig) WriteFile(path string) error {
+	if cs.comments {
+		return fmt.Errorf("%s: %w", path, errCSpellComments)
+	}
+
+	data, err := cs.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+var (
+	errCSpellNotAnObject = errors.New("cspell configuration is not an object")
+	errCSpellComments    = errors.New("cspell configuration contains comments, which would be lost when writing it; remove them or edit the file manually")
+)
+
+// UnmarshalJSON unmarshals a cspell configuration.
+// Comments and trailing commas are ignored.
+func (cs *CSpellConfig) UnmarshalJSON(data []byte) error {
+	data, cs.comments = stripComments(data)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	// read the opening brace of the object
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return errCSpellNotAnObject
+	}
+
+	cs.keys = nil
+	cs.fields = make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return errCSpellNotAnObject
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		if _, seen := cs.fields[key]; !seen {
+			cs.keys = append(cs.keys, key)
+		}
+		cs.fields[key] = value
+	}
+
+	// decode the known fields
+	for _, field := range cs.cspellFields() {
+		value, ok := cs.fields[field.key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(value, field.value); err != nil {
+			return fmt.Errorf("field %q: %w", field.key, err)
+		}
+	}
+	return nil
+}
+
+// stripComments replaces comments and trailing commas in data with spaces, so that it can be decoded as json.
+// It reports if data contained any comments.
+func stripComments(data []byte) (stripped []byte, comments bool) {
+	stripped = bytes.Clone(data)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if stripped[i] != '\n' {
+				stripped[i] = ' '
+			}
+		}
+	}
+
+	comma := -1 // position of the last comma not followed by anything but whitespace
+	for i := 0; i < len(stripped); i++ {
+		switch c := stripped[i]; {
+		case c == '"':
+			// skip over strings, including escaped quotes
+			for i++; i < len(stripped) && stripped[i] != '"'; i++ {
+				if stripped[i] == '\\' {
+					i++
+				}
+			}
+			comma = -1
+		case c == '/' && i+1 < len(stripped) && stripped[i+1] == '/':
+			end := bytes.IndexByte(stripped[i:], '\n')
+			if end < 0 {
+				end = len(stripped) - i
+			}
+			blank(i, i+end)
+			comments = true
+			i += end - 1
+		case c == '/' && i+1 < len(stripped) && stripped[i+1] == '*':
+			end := bytes.Index(stripped[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(stripped) - i - 2
+			} else {
+				end += len("*/")
+			}
+			blank(i, i+2+end)
+			comments = true
+			i += 2 + end - 1
+		case c == ',':
+			comma = i
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				stripped[comma] = ' '
+			}
+			comma = -1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			// whitespace does not end a trailing comma
+		default:
+			comma = -1
+		}
+	}
+	return stripped, comments
+}
+
+// MarshalJSON marshals this configuration as an indented json object.
+func (cs *CSpellConfig) MarshalJSON() ([]byte, error) {
+	keys := cs.keys
+	fields := make(map[string]json.RawMessage, len(cs.fields))
+	for key, value := range cs.fields {
+		fields[key] = value
+	}
+
+	// update the known fields, adding new ones at the end
+	for _, field := range cs.cspellFields() {
+		if field.readOnly {
+			continue
+		}
+
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+
+		// omit empty lists
+		if bytes.Equal(value, []byte("null")) || bytes.Equal(value, []byte("[]")) {
+			delete(fields, field.key)
+			continue
+		}
+
+		if _, ok := fields[field.key]; !ok {
+			keys = append(keys[:len(keys):len(keys)], field.key)
+		}
+		fields[field.key] = value
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	first := true
+	for _, key := range keys {
+		value, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if !first {
+			buffer.WriteString(",")
+		}
+		first = false
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.WriteString("\n\t")
+		buffer.Write(name)
+		buffer.WriteString(": ")
+		if err := json.Indent(&buffer, value, "\t", "\t"); err != nil {
+			return nil, err
+		}
+	}
+	buffer.WriteString("\n}")
+	return buffer.Bytes(), nil
+}
+
+// Known returns a dictionary containing the words and ignored words of this configuration.
+func (cs *CSpellConfig) Known() Dictionary {
+	dict := NewDictionary(cs.Words...)
+	dict.Add(cs.IgnoreWords...)
+	return dict
+}
+
+// Dictionary returns a dictionary containing the words and ignored words of this configuration,
+// as well as the words of all custom dictionaries defined in it.
+//
+// Relative paths of custom dictionaries are resolved relative to dir.
+func (cs *CSpellConfig) Dictionary(dir string) (Dictionary, error) {
+	dict := cs.Known()
+	for _, definition := range cs.DictionaryDefinitions {
+		if definition.Path == "" {
+			continue
+		}
+
+		path := definition.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		words, err := readWordList(path)
+		if err != nil {
+			return nil, fmt.Errorf("dictionary %q: %w", definition.Name, err)
+		}
+		dict.AddDictionary(words)
+	}
+	return dict, nil
+}
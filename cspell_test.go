@@ -0,0 +1,79 @@
+//spellchecker:words spellchecker
+package spellchecker_test
+
+//spellchecker:words path filepath reflect testing check spellchecker
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+func TestCSpellConfig_roundtrip(t *testing.T) {
+	input := `{"version": "0.2", "words": ["hello"], "ignorePaths": ["vendor", "*.sum"]}`
+
+	var cs spellchecker.CSpellConfig
+	if err := cs.UnmarshalJSON([]byte(input)); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if want := []string{"hello"}; !reflect.DeepEqual(cs.Words, want) {
+		t.Errorf("UnmarshalJSON() Words = %v, want %v", cs.Words, want)
+	}
+
+	cs.Words = append(cs.Words, "world")
+	cs.IgnoreWords = []string{"foo"}
+
+	got, err := cs.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	want := `{
+	"version": "0.2",
+	"words": [
+		"hello",
+		"world"
+	],
+	"ignorePaths": [
+		"vendor",
+		"*.sum"
+	],
+	"ignoreWords": [
+		"foo"
+	]
+}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestCSpellConfig_comments(t *testing.T) {
+	input := `// cspell configuration
+{
+	"version": "0.2", /* the version */
+	"words": [
+		"hello", // greeting
+		"http://example.com/*not-a-comment*/",
+	],
+}
+`
+	path := filepath.Join(t.TempDir(), "cspell.json")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, err := spellchecker.ReadCSpellConfig(path)
+	if err != nil {
+		t.Fatalf("ReadCSpellConfig() error = %v", err)
+	}
+	if want := []string{"hello", "http://example.com/*not-a-comment*/"}; !reflect.DeepEqual(cs.Words, want) {
+		t.Errorf("ReadCSpellConfig() Words = %v, want %v", cs.Words, want)
+	}
+
+	// comments would be lost
+	if err := cs.WriteFile(path); err == nil {
+		t.Errorf("WriteFile() did not return an error for a configuration with comments")
+	}
+}
@@ -0,0 +1,278 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words bufio path filepath strings sync embed
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	_ "embed"
+)
+
+//spellchecker:words occured inflectable withs
+
+//go:embed dictionary.txt
+var dictionaryText string
+
+//go:embed misspellings.txt
+var misspellingsText string
+
+// Dictionary is a set of known words.
+// Words are stored in lowercase and compared under case folding.
+type Dictionary map[string]struct{}
+
+// NewDictionary creates a new dictionary containing the given words.
+func NewDictionary(words ...string) Dictionary {
+	dict := make(Dictionary, len(words))
+	dict.Add(words...)
+	return dict
+}
+
+// DefaultDictionary returns a new copy of the built-in English dictionary.
+func DefaultDictionary() Dictionary {
+	dict := make(Dictionary, len(defaultDictionary()))
+	dict.AddDictionary(defaultDictionary())
+	return dict
+}
+
+// defaultDictionary returns the words of the built-in dictionary.
+// The returned dictionary must not be modified.
+func defaultDictionary() Dictionary {
+	words, _ := builtinDictionary()
+	return words
+}
+
+// builtinDictionary parses the built-in dictionary exactly once.
+// It returns all words of the dictionary, along with those words that may take simple inflections.
+// Inflectable words are marked with a trailing '+' in dictionary.txt.
+// The returned dictionaries must not be modified.
+var builtinDictionary = sync.OnceValues(func() (words, inflectable Dictionary) {
+	words, inflectable = make(Dictionary), make(Dictionary)
+
+	scanner := bufio.NewScanner(strings.NewReader(dictionaryText))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, ok := strings.CutSuffix(line, "+")
+		words.Add(word)
+		if ok {
+			inflectable.Add(word)
+		}
+	}
+	if scanner.Err() != nil {
+		panic("builtinDictionary: failed to read built-in dictionary")
+	}
+	return words, inflectable
+})
+
+// takesInflections reports if simple inflections of the given lowercase word are known words.
+// This holds for words of the built-in dictionary marked as inflectable, and all words not in the built-in dictionary.
+func takesInflections(word string) bool {
+	words, inflectable := builtinDictionary()
+	if _, ok := inflectable[word]; ok {
+		return true
+	}
+	_, ok := words[word]
+	return !ok
+}
+
+// misspellings parses the built-in list of known misspellings exactly once.
+// These are never considered to be inflections of known words.
+// The returned dictionary must not be modified.
+var misspellings = sync.OnceValue(func() Dictionary {
+	dict, err := parseWordList(strings.NewReader(misspellingsText))
+	if err != nil {
+		panic("misspellings: failed to read built-in list of misspellings")
+	}
+	return dict
+})
+
+// BuiltinDictionary is the name used to refer to the built-in dictionary in place of a path.
+const BuiltinDictionary = "builtin"
+
+// cspellDictionaries are the names of dictionaries bundled with cspell.
+var cspellDictionaries = []string{
+	"en_us", "en-us", "en-gb", "en_gb",
+	"companies", "softwareTerms", "software-terms", "public-licenses", "misc", "filetypes", "fullstack",
+	"go", "golang", "python", "cpp", "c", "csharp", "java", "php", "ruby", "rust", "scala", "swift",
+	"typescript", "node", "npm", "html", "css", "bash", "shell", "powershell", "lua", "latex", "markdown",
+	"docker", "k8s", "aws", "sql", "fonts", "lorem-ipsum", "people-names", "data-science", "cryptocurrencies",
+	BuiltinDictionary,
+}
+
+// dictionaryNames returns the names under which the dictionary at path can be referenced in a 'dictionaries' directive.
+// This is the name of the file without extension, along with the names of all dictionaries defined in a cspell configuration.
+func dictionaryNames(path string) ([]string, error) {
+	if path == BuiltinDictionary {
+		return nil, nil
+	}
+
+	names := []string{strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	if filepath.Ext(path) != ".json" {
+		return names, nil
+	}
+
+	cs, err := ReadCSpellConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range cs.DictionaryDefinitions {
+		names = append(names, definition.Name)
+	}
+	return names, nil
+}
+
+// ReadDictionary reads a dictionary from the given path.
+// If path is [BuiltinDictionary], returns the built-in dictionary instead.
+//
+// The format of the file is determined by its extension:
+//
+//   - '.json' files are read as cspell configuration files, see [CSpellConfig.Dictionary].
+//   - '.dic' files are read as hunspell dictionaries. Affix rules are not applied.
+//   - all other files are read as plain word lists, containing one word per line.
+//     Lines starting with '#' are ignored.
+func ReadDictionary(path string) (Dictionary, error) {
+	switch {
+	case path == BuiltinDictionary:
+		return DefaultDictionary(), nil
+	case filepath.Ext(path) == ".json":
+		cs, err := ReadCSpellConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		return cs.Dictionary(filepath.Dir(path))
+	case filepath.Ext(path) == ".dic":
+		return readHunspell(path)
+	default:
+		return readWordList(path)
+	}
+}
+
+// readWordList reads a plain word list from path.
+func readWordList(path string) (Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseWordList(file)
+}
+
+// parseWordList parses a list of words, one word per line.
+// Empty lines and lines starting with '#' are ignored.
+func parseWordList(reader io.Reader) (Dictionary, error) {
+	dict := make(Dictionary)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dict.Add(line)
+	}
+
+	return dict, scanner.Err()
+}
+
+// readHunspell reads the words from a hunspell dictionary at path.
+//
+// The first line of the dictionary (containing the approximate number of words) is skipped,
+// as are the affix flags of each word.
+func readHunspell(path string) (Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dict := make(Dictionary)
+
+	scanner := bufio.NewScanner(file)
+	for first := true; scanner.Scan(); first = false {
+		line := strings.TrimSpace(scanner.Text())
+		if first || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// remove morphological fields and affix flags
+		word, _, _ := strings.Cut(strings.Fields(line)[0], "/")
+		dict.Add(word)
+	}
+
+	return dict, scanner.Err()
+}
+
+// Add adds the given words to this dictionary.
+func (dict Dictionary) Add(words ...string) {
+	for _, word := range words {
+		dict[strings.ToLower(word)] = struct{}{}
+	}
+}
+
+// AddDictionary adds all words from other to this dictionary.
+func (dict Dictionary) AddDictionary(other Dictionary) {
+	for word := range other {
+		dict[word] = struct{}{}
+	}
+}
+
+// ContainsExactly is like Contains, but does not consider inflections of known words.
+func (dict Dictionary) ContainsExactly(word string) bool {
+	_, ok := dict[strings.ToLower(word)]
+	return ok
+}
+
+// inflections are suffixes that may be removed from a word to find it in the dictionary.
+// Each suffix is replaced by the corresponding replacement.
+var inflections = []struct{ suffix, replacement string }{
+	{"ies", "y"},
+	{"ied", "y"},
+	{"es", ""},
+	{"s", ""},
+	{"ed", ""},
+	{"ed", "e"},
+	{"ing", ""},
+	{"ing", "e"},
+	{"er", ""},
+	{"er", "e"},
+	{"ers", ""},
+	{"ers", "e"},
+	{"ly", ""},
+	{"'s", ""},
+}
+
+// Contains checks if the dictionary contains the given word.
+// Words are compared under case folding.
+//
+// Simple inflections of known words, such as plurals or past tenses, are also considered to be contained in the dictionary.
+// Words of the built-in dictionary only take inflections if they are marked as inflectable, so that e.g. "withs" is not a known word.
+// Known misspellings, such as "occured", are never considered to be inflections.
+func (dict Dictionary) Contains(word string) bool {
+	word = strings.ToLower(word)
+	if _, ok := dict[word]; ok {
+		return true
+	}
+	if _, ok := misspellings()[word]; ok {
+		return false
+	}
+
+	for _, inflection := range inflections {
+		stem, ok := strings.CutSuffix(word, inflection.suffix)
+		if !ok || stem == "" {
+			continue
+		}
+		stem += inflection.replacement
+		if _, ok := dict[stem]; ok && takesInflections(stem) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+//spellchecker:words spellchecker
+package spellchecker_test
+
+//spellchecker:words path filepath testing check spellchecker
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+)
+
+func TestDictionary_Contains(t *testing.T) {
+	dict := spellchecker.NewDictionary("hello", "parse", "directive", "dictionary", "commit", "frobnicate", "with")
+
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{word: "hello", want: true},
+		{word: "HELLO", want: true},
+		{word: "Hello", want: true},
+		{word: "parsed", want: true},
+		{word: "parsing", want: true},
+		{word: "parser", want: true},
+		{word: "directives", want: true},
+		{word: "dictionaries", want: true},
+		{word: "commits", want: true},
+		{word: "commited", want: false},
+		{word: "frobnicated", want: true},
+		{word: "frobnicates", want: true},
+		{word: "with", want: true},
+		{word: "withs", want: false},
+		{word: "withd", want: false},
+		{word: "helo", want: false},
+		{word: "world", want: false},
+		{word: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := dict.Contains(tt.word); got != tt.want {
+				t.Errorf("Dictionary.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultDictionary(t *testing.T) {
+	dict := spellchecker.DefaultDictionary()
+	for _, word := range []string{"the", "word", "identifier", "package", "configuration", "parsed", "commits", "tokenizing"} {
+		if !dict.Contains(word) {
+			t.Errorf("DefaultDictionary() does not contain %q", word)
+		}
+	}
+
+	// common misspellings and keyboard mashes are not words
+	for _, word := range []string{"occured", "wierd", "commited", "recieved", "aaaaarrrrrrggghhh", "brrrrr"} {
+		if dict.Contains(word) {
+			t.Errorf("DefaultDictionary() contains %q", word)
+		}
+	}
+
+	// inflections of words that cannot take them are not words
+	for _, word := range []string{"thed", "fromd", "withd", "withs", "theer", "thely", "andly", "froming", "alot"} {
+		if dict.Contains(word) {
+			t.Errorf("DefaultDictionary() contains %q", word)
+		}
+	}
+}
+
+func TestReadDictionary(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"words.txt":   "# comment\nhello\n\nWorld\n",
+		"en.dic":      "2\nhello/SM\nworld po:noun\n",
+		"custom.txt":  "world\n",
+		"cspell.json": `{"words": ["hello"], "dictionaryDefinitions": [{"name": "custom", "path": "./custom.txt"}]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"words.txt", "en.dic", "cspell.json"} {
+		t.Run(name, func(t *testing.T) {
+			dict, err := spellchecker.ReadDictionary(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatalf("ReadDictionary() error = %v", err)
+			}
+			if len(dict) != 2 || !dict.Contains("hello") || !dict.Contains("world") {
+				t.Errorf("ReadDictionary() = %v, want hello and world", dict)
+			}
+		})
+	}
+}
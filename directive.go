@@ -0,0 +1,107 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token strings unicode
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// directive is a spellchecker directive found in a comment.
+type directive struct {
+	CommentText
+
+	comment  *ast.Comment // the comment containing the directive
+	pos, end token.Pos    // position of the directive, for line comments this is the entire comment
+	block    bool         // is the directive part of a block comment?
+}
+
+// parseDirectives parses all spellchecker directives in comment.
+//
+// A line comment ('//') contains at most one directive.
+// A block comment ('/* */') may contain one directive per line.
+// Lines of a block comment may be decorated with a leading '*'.
+func parseDirectives(cfg *Config, comment *ast.Comment) (directives []directive) {
+	// line comment
+	if text, ok := strings.CutPrefix(comment.Text, "//"); ok {
+		if ct, ok := cfg.Parse(text); ok {
+			directives = append(directives, directive{CommentText: ct, comment: comment, pos: comment.Pos(), end: comment.End()})
+		}
+		return
+	}
+
+	// block comment
+	forEachBlockLine(comment.Text, func(offset int, line string) {
+		start, text := trimBlockLine(line)
+		if ct, ok := cfg.Parse(text); ok {
+			pos := comment.Pos() + token.Pos(offset+start)
+			directives = append(directives, directive{CommentText: ct, comment: comment, pos: pos, end: pos + token.Pos(len(text)), block: true})
+		}
+	})
+	return
+}
+
+// forEachBlockLine calls f for each line of the block comment text, along with the byte offset of the line in text.
+// The opening '/*' and closing '*/' are not part of any line, lines do not include the trailing newline.
+func forEachBlockLine(text string, f func(offset int, line string)) {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	offset := len("/*")
+	for line := range strings.SplitAfterSeq(text, "\n") {
+		f(offset, strings.TrimRight(line, "\r\n"))
+		offset += len(line)
+	}
+}
+
+// trimBlockLine removes surrounding spaces and a leading '*' decoration from a line of a block comment.
+// It returns the trimmed text, along with its byte offset in line.
+func trimBlockLine(line string) (offset int, text string) {
+	text = strings.TrimLeftFunc(line, unicode.IsSpace)
+	if rest, ok := strings.CutPrefix(text, "*"); ok {
+		text = strings.TrimLeftFunc(rest, unicode.IsSpace)
+	}
+	return len(line) - len(text), strings.TrimRightFunc(text, unicode.IsSpace)
+}
+
+// forEachDirective calls f for each spellchecker directive in file, in the order they occur in.
+func forEachDirective(cfg *Config, file *ast.File, f func(d directive)) {
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			for _, d := range parseDirectives(cfg, comment) {
+				f(d)
+			}
+		}
+	}
+}
+
+// convertBlockComment returns the line comments replacing the block comment containing directives,
+// each of which is normalized using normalize.
+// Lines of the returned text are separated by a newline followed by indent.
+//
+// If the comment contains anything but directives and blank lines, returns false.
+func convertBlockComment(cfg *Config, comment *ast.Comment, indent string, normalize func(ct CommentText) CommentText) (string, bool) {
+	if !strings.HasPrefix(comment.Text, "/*") {
+		return "", false
+	}
+
+	ok := true
+	var lines []string
+	forEachBlockLine(comment.Text, func(_ int, line string) {
+		_, text := trimBlockLine(line)
+		if text == "" {
+			return
+		}
+
+		ct, isDirective := cfg.Parse(text)
+		if !isDirective {
+			ok = false
+			return
+		}
+		lines = append(lines, "//"+normalize(ct).String())
+	})
+	if !ok || len(lines) == 0 {
+		return "", false
+	}
+	return strings.Join(lines, "\n"+indent), true
+}
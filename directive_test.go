@@ -0,0 +1,92 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words parser token testing
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestParseDirectives(t *testing.T) {
+	const source = `package example
+
+//cspell:words hello world
+/* spellchecker:ignore foo */
+/*
+ * cspell:flagWords bar
+ * not a directive
+   spellchecker:disable-next-line
+*/
+var x = 1
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []directive
+	forEachDirective(DefaultConfig(), file, func(d directive) {
+		got = append(got, d)
+	})
+
+	want := []struct {
+		text  string
+		block bool
+		line  int
+	}{
+		{text: "cspell:words hello world", block: false, line: 3},
+		{text: "spellchecker:ignore foo", block: true, line: 4},
+		{text: "cspell:flagWords bar", block: true, line: 6},
+		{text: "spellchecker:disable-next-line", block: true, line: 8},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("forEachDirective() found %d directives, want %d", len(got), len(want))
+	}
+	for i, d := range got {
+		if d.String() != want[i].text {
+			t.Errorf("directive %d = %q, want %q", i, d.String(), want[i].text)
+		}
+		if d.block != want[i].block {
+			t.Errorf("directive %d block = %v, want %v", i, d.block, want[i].block)
+		}
+		if line := fset.Position(d.pos).Line; line != want[i].line {
+			t.Errorf("directive %d on line %d, want %d", i, line, want[i].line)
+		}
+
+		// the position must cover exactly the directive
+		if !d.block {
+			continue
+		}
+		offset := d.pos - d.comment.Pos()
+		if text := d.comment.Text[offset : offset+(d.end-d.pos)]; text != want[i].text {
+			t.Errorf("directive %d covers %q, want %q", i, text, want[i].text)
+		}
+	}
+}
+
+func TestConvertBlockComment(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   string
+		wantOk bool
+	}{
+		{text: "/* cspell:words hello */", want: "//spellchecker:words hello", wantOk: true},
+		{text: "/*\n * cspell:words hello\n *\n * spellchecker:DISABLE\n */", want: "//spellchecker:words hello\n\t//spellchecker:disable", wantOk: true},
+		{text: "/* cspell:words hello\n some prose */", wantOk: false},
+		{text: "/* some prose */", wantOk: false},
+		{text: "/* */", wantOk: false},
+		{text: "//spellchecker:words hello", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, gotOk := convertBlockComment(DefaultConfig(), &ast.Comment{Text: tt.text}, "\t", CommentText.Normalize)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("convertBlockComment() = %q, %v, want %q, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words flag strconv strings sync golang tools analysis
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() {
+	for _, analyzer := range Analyzers() {
+		registerConfigFlags(&analyzer.Flags)
+	}
+}
+
+// CloneAnalyzer returns a copy of analyzer with its own, unset, configuration flags.
+// Setting the flags of the copy does not affect analyzer itself, nor any other analyzer.
+func CloneAnalyzer(analyzer *analysis.Analyzer) *analysis.Analyzer {
+	clone := *analyzer
+	clone.Flags = flag.FlagSet{}
+	registerConfigFlags(&clone.Flags)
+	return &clone
+}
+
+// registerConfigFlags registers new flags overriding the configuration in flags.
+func registerConfigFlags(flags *flag.FlagSet) {
+	newConfigFlags().register(flags)
+}
+
+// configFlags are the flags overriding the configuration of a single analyzer.
+type configFlags struct {
+	minWordLength intFlag
+	excludeWords  listFlag
+	keywords      listFlag
+}
+
+// newConfigFlags creates a new set of unset configuration flags.
+func newConfigFlags() *configFlags {
+	return &configFlags{
+		minWordLength: intFlag{apply: func(cfg *Config, value int) { cfg.MinWordLength = value }},
+		excludeWords:  listFlag{apply: func(cfg *Config, values []string) { cfg.ExcludeWords = values }},
+		keywords:      listFlag{apply: func(cfg *Config, values []string) { cfg.Keywords = values }},
+	}
+}
+
+// register registers the configuration flags in flags.
+// See [configFlag].
+func (cf *configFlags) register(flags *flag.FlagSet) {
+	flags.Var(&cf.minWordLength, "min-word-length", "minimal length of words added to directives or reported as misspelled (overrides configuration file)")
+	flags.Var(&cf.excludeWords, "exclude-words", "comma-separated list of words never added to directives or reported as misspelled (overrides configuration file)")
+	flags.Var(&cf.keywords, "keywords", "comma-separated list of keywords introducing a directive (overrides configuration file)")
+}
+
+// configFlag is a flag overriding a setting of the configuration.
+type configFlag interface {
+	flag.Value
+
+	// override overrides the setting in cfg if the flag was set and cfg is not nil.
+	// It reports if the flag was set.
+	override(cfg *Config) bool
+}
+
+// overrideConfig returns a copy of cfg with all configuration flags in flags applied.
+// If no flag was set, returns cfg itself.
+//
+// Overridden configurations are cached by cfg and the values of the flags,
+// so that analyzers with the same flag values also share the overridden configuration.
+func overrideConfig(cfg *Config, flags *flag.FlagSet) (*Config, error) {
+	key := overrideKey{base: cfg}
+	flags.VisitAll(func(f *flag.Flag) {
+		if value, ok := f.Value.(configFlag); ok && value.override(nil) {
+			key.flags += f.Name + "=" + value.String() + "\n"
+		}
+	})
+	if key.flags == "" {
+		return cfg, nil
+	}
+
+	overrideCacheMutex.Lock()
+	defer overrideCacheMutex.Unlock()
+
+	if override, ok := overrideCache[key]; ok {
+		return override, nil
+	}
+
+	override := *cfg
+	flags.VisitAll(func(f *flag.Flag) {
+		if value, ok := f.Value.(configFlag); ok {
+			value.override(&override)
+		}
+	})
+	if err := override.init(); err != nil {
+		return nil, err
+	}
+
+	overrideCache[key] = &override
+	return &override, nil
+}
+
+// overrideKey identifies a configuration overridden by flags.
+type overrideKey struct {
+	base  *Config // the configuration being overridden
+	flags string  // names and values of the set flags
+}
+
+var (
+	overrideCacheMutex sync.Mutex
+	overrideCache      = make(map[overrideKey]*Config)
+)
+
+// intFlag is a configFlag holding an integer.
+type intFlag struct {
+	value int
+	set   bool
+	apply func(cfg *Config, value int)
+}
+
+func (i *intFlag) String() string {
+	if !i.set {
+		return ""
+	}
+	return strconv.Itoa(i.value)
+}
+
+func (i *intFlag) Set(value string) (err error) {
+	i.value, err = strconv.Atoi(value)
+	i.set = err == nil
+	return err
+}
+
+func (i *intFlag) override(cfg *Config) bool {
+	if i.set && cfg != nil {
+		i.apply(cfg, i.value)
+	}
+	return i.set
+}
+
+// listFlag is a configFlag holding a comma-separated list of strings.
+type listFlag struct {
+	values []string
+	set    bool
+	apply  func(cfg *Config, values []string)
+}
+
+func (l *listFlag) String() string {
+	return strings.Join(l.values, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	l.values = nil
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			l.values = append(l.values, v)
+		}
+	}
+	l.set = true
+	return nil
+}
+
+func (l *listFlag) override(cfg *Config) bool {
+	if l.set && cfg != nil {
+		l.apply(cfg, l.values)
+	}
+	return l.set
+}
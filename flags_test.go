@@ -0,0 +1,104 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words flag reflect testing
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestOverrideConfig(t *testing.T) {
+	var flags flag.FlagSet
+	registerConfigFlags(&flags)
+
+	cfg := DefaultConfig()
+
+	// no flags set => configuration is returned as is
+	got, err := overrideConfig(cfg, &flags)
+	if err != nil || got != cfg {
+		t.Fatalf("overrideConfig() = %v, %v, want unchanged configuration", got, err)
+	}
+
+	if err := flags.Parse([]string{"-min-word-length", "2", "-exclude-words", "foo, bar", "-keywords", "cspell"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = overrideConfig(cfg, &flags)
+	if err != nil {
+		t.Fatalf("overrideConfig() error = %v", err)
+	}
+	if got.MinWordLength != 2 {
+		t.Errorf("overrideConfig() MinWordLength = %d, want 2", got.MinWordLength)
+	}
+	if want := []string{"foo", "bar"}; !reflect.DeepEqual(got.ExcludeWords, want) {
+		t.Errorf("overrideConfig() ExcludeWords = %v, want %v", got.ExcludeWords, want)
+	}
+	if want := []string{"cspell", "spellchecker"}; !reflect.DeepEqual(got.Keywords, want) {
+		t.Errorf("overrideConfig() Keywords = %v, want %v", got.Keywords, want)
+	}
+
+	// original configuration is unchanged
+	if cfg.MinWordLength != 4 {
+		t.Errorf("overrideConfig() modified the original configuration")
+	}
+}
+
+func TestOverrideConfig_perAnalyzer(t *testing.T) {
+	var first, second, third flag.FlagSet
+	registerConfigFlags(&first)
+	registerConfigFlags(&second)
+	registerConfigFlags(&third)
+
+	if err := first.Set("min-word-length", "5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := third.Set("min-word-length", "5"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	a, err := overrideConfig(cfg, &first)
+	if err != nil {
+		t.Fatalf("overrideConfig() error = %v", err)
+	}
+	b, err := overrideConfig(cfg, &second)
+	if err != nil {
+		t.Fatalf("overrideConfig() error = %v", err)
+	}
+	c, err := overrideConfig(cfg, &third)
+	if err != nil {
+		t.Fatalf("overrideConfig() error = %v", err)
+	}
+
+	// setting a flag of one analyzer does not override the configuration of another
+	if a.MinWordLength != 5 {
+		t.Errorf("overrideConfig() MinWordLength = %d, want 5", a.MinWordLength)
+	}
+	if b != cfg {
+		t.Errorf("overrideConfig() = %v, want unchanged configuration", b)
+	}
+
+	// the overridden configuration is only initialized once for the same flag values
+	if a != c {
+		t.Errorf("overrideConfig() returned different configurations for the same flags")
+	}
+}
+
+func TestAnalyzers_flags(t *testing.T) {
+	analyzers := Analyzers()
+	if err := analyzers[0].Flags.Set("min-word-length", "5"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		// reset the flag for other tests
+		value := analyzers[0].Flags.Lookup("min-word-length").Value.(*intFlag)
+		*value = intFlag{apply: value.apply}
+	}()
+
+	for _, analyzer := range analyzers[1:] {
+		if got := analyzer.Flags.Lookup("min-word-length").Value.String(); got != "" {
+			t.Errorf("%s: min-word-length = %q, want unset", analyzer.Name, got)
+		}
+	}
+}
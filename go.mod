@@ -1,13 +1,16 @@
+// spellchecker:words check spellchecker github golangci plugin module register pkglib golang tools gopkg yaml sync
 module go.tkw01536.de/go-check-spellchecker
 
 go 1.26.5
 
 require (
+	github.com/golangci/plugin-module-register v0.1.2
 	go.tkw01536.de/pkglib v0.0.0-20260703071639-6b0b0b91646c
+	golang.org/x/mod v0.37.0
 	golang.org/x/tools v0.47.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	golang.org/x/mod v0.37.0 // indirect
 	golang.org/x/sync v0.21.0 // indirect
 )
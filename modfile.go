@@ -0,0 +1,148 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words errors slices strings golang modfile
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+var errNoModule = errors.New("missing module directive")
+
+// modSyntax is the comment syntax of go.mod files.
+var modSyntax = CommentSyntax{Line: "//"}
+
+// CheckModFile checks that the header of a go.mod file, that is the comments before the module directive, has exactly one 'spellchecker:words' directive.
+// The directive contains the words of the module path, the paths of all required modules and of all replace directives.
+// Other directives in the header are checked as by [Config.CheckText].
+//
+// The name of the file is only used in error messages.
+// Positions of diagnostics and fixes are byte offsets into data.
+func (cfg *Config) CheckModFile(name string, data []byte) ([]TextDiagnostic, error) {
+	file, err := modfile.Parse(name, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if file.Module == nil {
+		return nil, errNoModule
+	}
+
+	text := string(data)
+	moduleStart := file.Module.Syntax.Start.Byte
+
+	// other directives in the header are checked like those of any other file
+	var (
+		directives  []textDirective
+		diagnostics []TextDiagnostic
+	)
+	for _, d := range cfg.textDirectives(text[:moduleStart], modSyntax) {
+		if d.IsDirective("words") {
+			directives = append(directives, d)
+			continue
+		}
+		diagnostics = append(diagnostics, cfg.checkTextDirective(text, modSyntax, d)...)
+	}
+
+	words := makeModuleWords(cfg, file)
+	diagnostic := func(start, end int, message, fix string, fixStart, fixEnd int, newText string) TextDiagnostic {
+		return TextDiagnostic{
+			Start: start, End: end,
+			Category: CategoryDirective,
+			Message:  message,
+			Fix:      &TextFix{Message: fix, Start: fixStart, End: fixEnd, NewText: newText},
+		}
+	}
+
+	remove := func(d textDirective, message string) TextDiagnostic {
+		start, end := d.removal(text, modSyntax)
+		return diagnostic(d.start, d.end, message, "remove extra directive", start, end, "")
+	}
+
+	// want no directive, but there are some
+	if len(words) == 0 {
+		for _, d := range directives {
+			diagnostics = append(diagnostics, remove(d, "'spellchecker:words' directive in module header should only refer to module words (of which there are none)"))
+		}
+		return diagnostics, nil
+	}
+
+	lines := cfg.wrapWordsDirective(words)
+	want := "// " + strings.Join(lines, "\n// ")
+
+	// want a directive, but there is none
+	if len(directives) == 0 {
+		// insert above the comments of the module directive, so that the directive does not become part of them,
+		// as for example a deprecation notice.
+		insert, newText := moduleStart, want+"\n"
+		if before := file.Module.Syntax.Before; len(before) > 0 {
+			insert, newText = before[0].Start.Byte, want+"\n\n"
+		}
+		return append(diagnostics, diagnostic(
+			moduleStart, file.Module.Syntax.End.Byte,
+			"missing 'spellchecker:words' directive in module header",
+			"insert 'spellchecker:words' directive in module header",
+			insert, insert, newText,
+		)), nil
+	}
+
+	// update the first group of consecutive directives
+	groups := [][]textDirective{directives[:1]}
+	for i, d := range directives[1:] {
+		if prev := directives[i]; strings.Trim(text[prev.commentEnd:d.commentStart], " \t\r") == "\n" {
+			groups[len(groups)-1] = append(groups[len(groups)-1], d)
+			continue
+		}
+		groups = append(groups, []textDirective{d})
+	}
+
+	group := groups[0]
+	first, last := group[0], group[len(group)-1]
+	if text[first.commentStart:last.commentEnd] != want {
+		diagnostics = append(diagnostics, diagnostic(
+			first.start, last.end,
+			"'spellchecker:words' directive in module header should only contain module words",
+			"update module words directive",
+			first.commentStart, last.commentEnd, want,
+		))
+	}
+
+	// extra directives should be removed
+	for _, group := range groups[1:] {
+		for _, d := range group {
+			diagnostics = append(diagnostics, remove(d, "there should be at most one 'spellchecker:words' directive in module header"))
+		}
+	}
+
+	slices.SortStableFunc(diagnostics, func(a, b TextDiagnostic) int { return a.Start - b.Start })
+	return diagnostics, nil
+}
+
+// makeModuleWords returns the words in the module path, the required module paths and the paths of replace directives of file.
+// Words are returned in the order they first occur in, excluding the configured words.
+func makeModuleWords(cfg *Config, file *modfile.File) []string {
+	var words []string
+	seen := make(Dictionary)
+
+	add := func(path string) {
+		for _, word := range cfg.splitWords(path) {
+			if !cfg.isDirectiveWord(word) || seen.ContainsExactly(word) {
+				continue
+			}
+			seen.Add(word)
+			words = append(words, word)
+		}
+	}
+
+	add(file.Module.Mod.Path)
+	for _, req := range file.Require {
+		add(req.Mod.Path)
+	}
+	for _, rep := range file.Replace {
+		add(rep.Old.Path)
+		add(rep.New.Path)
+	}
+	return words
+}
@@ -0,0 +1,117 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words reflect slices testing golang modfile
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+)
+
+func TestConfig_CheckModFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantMessages []string
+		wantFixed    string
+	}{
+		{
+			name:         "missing directive",
+			data:         "module example.com/frobnicate\n\nrequire golang.org/x/tools v0.1.0\n",
+			wantMessages: []string{"missing 'spellchecker:words' directive in module header"},
+			wantFixed:    "// spellchecker:words example frobnicate golang tools\nmodule example.com/frobnicate\n\nrequire golang.org/x/tools v0.1.0\n",
+		},
+		{
+			name:         "missing directive above module comment",
+			data:         "// Header.\n\n// Module example.\nmodule example.com/frobnicate\n",
+			wantMessages: []string{"missing 'spellchecker:words' directive in module header"},
+			wantFixed:    "// Header.\n\n// spellchecker:words example frobnicate\n\n// Module example.\nmodule example.com/frobnicate\n",
+		},
+		{
+			name:         "missing directive above deprecation",
+			data:         "// Deprecated: use example.com/other instead.\nmodule example.com/frobnicate\n",
+			wantMessages: []string{"missing 'spellchecker:words' directive in module header"},
+			wantFixed:    "// spellchecker:words example frobnicate\n\n// Deprecated: use example.com/other instead.\nmodule example.com/frobnicate\n",
+		},
+		{
+			name:         "extra directives",
+			data:         "// spellchecker:words example frobnicate\n\n// spellchecker:words stale words\n// spellchecker:words more\nmodule example.com/frobnicate\n",
+			wantMessages: []string{"there should be at most one 'spellchecker:words' directive in module header", "there should be at most one 'spellchecker:words' directive in module header"},
+			wantFixed:    "// spellchecker:words example frobnicate\n\nmodule example.com/frobnicate\n",
+		},
+		{
+			name:         "other directives",
+			data:         "// spellchecker:words example frobnicate\n// spellchecker:wrods widget\n//spellchecker:ignore   widget\n\nmodule example.com/frobnicate\n",
+			wantMessages: []string{"unknown directive 'wrods', did you mean 'words'?", "improperly formatted 'ignore' directive"},
+			wantFixed:    "// spellchecker:words example frobnicate\n// spellchecker:words widget\n//spellchecker:ignore widget\n\nmodule example.com/frobnicate\n",
+		},
+		{
+			name:      "up to date directive",
+			data:      "// spellchecker:words example frobnicate\n\nmodule example.com/frobnicate\n",
+			wantFixed: "// spellchecker:words example frobnicate\n\nmodule example.com/frobnicate\n",
+		},
+		{
+			name:         "outdated directive",
+			data:         "//cspell:words example\n// spellchecker:words outdated\nmodule example.com/frobnicate\n\nreplace example.com/widget => ../widget\n",
+			wantMessages: []string{"'spellchecker:words' directive in module header should only contain module words"},
+			wantFixed:    "// spellchecker:words example frobnicate widget\nmodule example.com/frobnicate\n\nreplace example.com/widget => ../widget\n",
+		},
+		{
+			name:         "no words",
+			data:         "// spellchecker:words outdated\nmodule a.b/c\n",
+			wantMessages: []string{"'spellchecker:words' directive in module header should only refer to module words (of which there are none)"},
+			wantFixed:    "module a.b/c\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := DefaultConfig().CheckModFile("go.mod", []byte(tt.data))
+			if err != nil {
+				t.Fatalf("CheckModFile() error = %v", err)
+			}
+
+			var (
+				messages []string
+				fixes    []*TextFix
+			)
+			for _, diagnostic := range diagnostics {
+				messages = append(messages, diagnostic.Message)
+				if diagnostic.Fix != nil {
+					fixes = append(fixes, diagnostic.Fix)
+				}
+			}
+
+			// apply fixes from the end, so that earlier offsets remain valid
+			slices.SortStableFunc(fixes, func(a, b *TextFix) int { return b.Start - a.Start })
+			fixed := tt.data
+			for _, fix := range fixes {
+				fixed = fixed[:fix.Start] + fix.NewText + fixed[fix.End:]
+			}
+			if !reflect.DeepEqual(messages, tt.wantMessages) {
+				t.Errorf("CheckModFile() messages = %q, want %q", messages, tt.wantMessages)
+			}
+			if fixed != tt.wantFixed {
+				t.Errorf("CheckModFile() fixed = %q, want %q", fixed, tt.wantFixed)
+			}
+
+			// fixes do not change the meaning of comments, such as deprecation notices
+			before, err := modfile.Parse("go.mod", []byte(tt.data), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := modfile.Parse("go.mod", []byte(fixed), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if before.Module.Deprecated != after.Module.Deprecated {
+				t.Errorf("CheckModFile() fix changed deprecation from %q to %q", before.Module.Deprecated, after.Module.Deprecated)
+			}
+		})
+	}
+
+	if _, err := DefaultConfig().CheckModFile("go.mod", []byte("go 1.22\n")); err == nil {
+		t.Errorf("CheckModFile() without module directive did not return an error")
+	}
+}
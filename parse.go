@@ -1,16 +1,28 @@
 //spellchecker:words spellchecker
 package spellchecker
 
-//spellchecker:words strings unicode
+//spellchecker:words regexp strings unicode
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"unicode"
 )
 
 const correctKeyword = "spellchecker"
 
-var keywords = []string{correctKeyword, "cSpell", "spell-checker"}
+// defaultKeywords are the keywords recognized by default.
+var defaultKeywords = []string{correctKeyword, "cSpell", "spell-checker"}
+
+// Directives are the names of all directives known to the spellchecker, in their canonical form.
+// These are the directives understood by cspell, along with the spellchecker specific 'check-strings' and 'ignore-strings' directives.
+var Directives = []string{
+	"words", "ignore", "flagWords", "ignoreRegExp",
+	"language", "locale", "dictionaries",
+	"disable", "enable", "disable-line", "disable-next-line", "disable-next",
+	"enableCompoundWords", "disableCompoundWords", "enableCaseSensitive", "disableCaseSensitive",
+	"check-strings", "ignore-strings",
+}
 
 // CommentText represents the text belonging to a parsed spellchecker directive.
 // A comment looks like:
@@ -31,15 +43,151 @@ func (ct CommentText) IsDirective(directive string) bool {
 	return strings.EqualFold(ct.Directive, directive)
 }
 
+// Scope describes which part of a file a directive enables or disables the spellchecker for.
+type Scope int
+
+const (
+	// ScopeNone indicates that a directive does not enable or disable the spellchecker.
+	ScopeNone Scope = iota
+
+	// ScopeDisable disables the spellchecker until the next 'enable' directive, or the end of the file.
+	// It is used by the 'disable' directive.
+	ScopeDisable
+
+	// ScopeEnable enables the spellchecker after a preceding 'disable' directive.
+	// It is used by the 'enable' directive.
+	ScopeEnable
+
+	// ScopeDisableLine disables the spellchecker for the line the directive is on.
+	// It is used by the 'disable-line' directive.
+	ScopeDisableLine
+
+	// ScopeDisableNextLine disables the spellchecker for the line following the directive.
+	// It is used by the 'disable-next-line' and 'disable-next' directives.
+	ScopeDisableNextLine
+)
+
+// Scope returns the scope of this directive.
+func (ct CommentText) Scope() Scope {
+	switch {
+	case ct.IsDirective("disable"):
+		return ScopeDisable
+	case ct.IsDirective("enable"):
+		return ScopeEnable
+	case ct.IsDirective("disable-line"):
+		return ScopeDisableLine
+	case ct.IsDirective("disable-next-line"), ct.IsDirective("disable-next"):
+		return ScopeDisableNextLine
+	default:
+		return ScopeNone
+	}
+}
+
+// Words parses the value of a 'words', 'ignore' or 'flagWords' directive into a list of words.
+func (ct CommentText) Words() []string {
+	return SplitWords(ct.Value)
+}
+
+// RegExp parses the value of an 'ignoreRegExp' directive.
+//
+// The value is either a regular expression enclosed in slashes and optionally followed by flags, such as '/0x[0-9a-f]+/gi',
+// or a plain regular expression.
+// Of the flags, 'i', 'm' and 's' are supported, all other flags are ignored.
+func (ct CommentText) RegExp() (*regexp.Regexp, error) {
+	pattern := ct.Value
+
+	if len(pattern) >= 2 && pattern[0] == '/' {
+		if end := strings.LastIndexByte(pattern, '/'); end > 0 {
+			var prefix string
+			for _, flag := range pattern[end+1:] {
+				if strings.ContainsRune("ims", flag) && !strings.ContainsRune(prefix, flag) {
+					prefix += string(flag)
+				}
+			}
+			pattern = pattern[1:end]
+			if prefix != "" {
+				pattern = "(?" + prefix + ")" + pattern
+			}
+		}
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// Languages parses the value of a 'language' or 'locale' directive, a comma-separated list of locales such as 'en,en-GB'.
+func (ct CommentText) Languages() []string {
+	return splitList(ct.Value)
+}
+
+// Dictionaries parses the value of a 'dictionaries' directive, a list of dictionary names separated by spaces or commas.
+func (ct CommentText) Dictionaries() []string {
+	return splitList(ct.Value)
+}
+
+// splitList splits value into a list of non-empty items separated by spaces or commas.
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+}
+
 // CommentText returns a normalized copy of comment.
 //
-// The picks the default keyword, and lowercases the directive.
+// The picks the default keyword, and uses the canonical form of the directive.
+// Directives not in [Directives] are lowercased.
 func (ct CommentText) Normalize() CommentText {
 	ct.Keyword = correctKeyword
-	ct.Directive = strings.ToLower(ct.Directive)
+	ct.Directive = canonicalDirective(ct.Directive)
 	return ct
 }
 
+// canonicalDirective returns the canonical form of the given directive.
+func canonicalDirective(directive string) string {
+	for _, known := range Directives {
+		if strings.EqualFold(directive, known) {
+			return known
+		}
+	}
+	return strings.ToLower(directive)
+}
+
+// closestDirective returns the known directive closest to the given one by edit distance, compared under case folding.
+// If no known directive is close enough to be a likely typo, returns false.
+func closestDirective(directive string) (closest string, ok bool) {
+	directive = strings.ToLower(directive)
+
+	best := len(directive)/2 + 1
+	for _, known := range Directives {
+		if distance := editDistance(directive, strings.ToLower(known)); distance < best {
+			closest, best, ok = known, distance, true
+		}
+	}
+	return
+}
+
+// editDistance returns the levenshtein distance between a and b, counted in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// the previous and current row of the distance matrix
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range ra {
+		cur[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			cur[j+1] = min(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
 // String formats the contents of this CommentText, that is it brings it back into the form:
 //
 // Keyword:Directive Value
@@ -61,6 +209,11 @@ func (ct CommentText) Text() string {
 // Parse parses the given text into a comment.
 // If the comment does not represent a text, returns false.
 func (ct *CommentText) Parse(text string) bool {
+	return ct.ParseKeywords(text, defaultKeywords)
+}
+
+// ParseKeywords is like Parse, but recognizes the given keywords instead of the default ones.
+func (ct *CommentText) ParseKeywords(text string, keywords []string) bool {
 	// if there is a newline, it can't be a directive.
 	if strings.ContainsRune(text, '\n') {
 		return false
@@ -46,3 +46,82 @@ func TestParseSpellDirective(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentText_RegExp(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantMatch string
+		wantErr   bool
+	}{
+		{value: `0x[0-9a-f]+`, wantMatch: "0xdeadbeef"},
+		{value: `/0x[0-9a-f]+/`, wantMatch: "0xdeadbeef"},
+		{value: `/0x[0-9a-f]+/gi`, wantMatch: "0xDEADBEEF"},
+		{value: `/a/b/`, wantMatch: "a/b"},
+		{value: `[unclosed`, wantErr: true},
+		{value: `/[unclosed/g`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ct := CommentText{Keyword: "spellchecker", Directive: "ignoreRegExp", Value: tt.value}
+			got, err := ct.RegExp()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CommentText.RegExp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if match := got.FindString("value " + tt.wantMatch + " value"); match != tt.wantMatch {
+				t.Errorf("CommentText.RegExp() matched %q, want %q", match, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestCommentText_Normalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "cSpell:words hello", want: "spellchecker:words hello"},
+		{text: "cspell:FLAGWORDS hello", want: "spellchecker:flagWords hello"},
+		{text: "spell-checker:ignoreregexp /x/", want: "spellchecker:ignoreRegExp /x/"},
+		{text: "spellchecker:Disable-Next-Line", want: "spellchecker:disable-next-line"},
+		{text: "spellchecker:Unknown value", want: "spellchecker:unknown value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var ct CommentText
+			if !ct.Parse(tt.text) {
+				t.Fatalf("CommentText.Parse() failed")
+			}
+			if got := ct.Normalize().String(); got != tt.want {
+				t.Errorf("CommentText.Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_closestDirective(t *testing.T) {
+	tests := []struct {
+		directive string
+		want      string
+		wantOk    bool
+	}{
+		{directive: "wrods", want: "words", wantOk: true},
+		{directive: "word", want: "words", wantOk: true},
+		{directive: "FlagWord", want: "flagWords", wantOk: true},
+		{directive: "disable-nextline", want: "disable-next-line", wantOk: true},
+		{directive: "ignoreregex", want: "ignoreRegExp", wantOk: true},
+		{directive: "dictionary", want: "dictionaries", wantOk: true},
+		{directive: "xyz", want: "", wantOk: false},
+		{directive: "something-else", want: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.directive, func(t *testing.T) {
+			got, gotOk := closestDirective(tt.directive)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("closestDirective() = %q, %v, want %q, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
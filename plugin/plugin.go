@@ -0,0 +1,88 @@
+//spellchecker:words plugin
+package plugin
+
+//spellchecker:words strconv strings github golangci plugin module register check spellchecker golang tools analysis
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golangci/plugin-module-register/register"
+	spellchecker "go.tkw01536.de/go-check-spellchecker"
+	"golang.org/x/tools/go/analysis"
+)
+
+// register the plugin when this package is imported by a custom golangci-lint binary
+func init() {
+	register.Plugin(Name, New)
+}
+
+// Name is the name the plugin is registered under.
+const Name = "spellchecker"
+
+// Settings are the settings of the plugin, as read from the 'settings' key in '.golangci.yml'.
+//
+// Settings that are not set retain the value from the spellchecker configuration file, see [spellchecker.LoadConfig].
+type Settings struct {
+	// MinWordLength overrides the minimal length of words.
+	MinWordLength *int `json:"minWordLength"`
+
+	// ExcludeWords overrides the words never added to directives or reported as misspelled.
+	ExcludeWords []string `json:"excludeWords"`
+
+	// Keywords overrides the keywords introducing a directive.
+	Keywords []string `json:"keywords"`
+}
+
+// flags returns the configuration flags corresponding to the settings.
+func (settings Settings) flags() map[string]string {
+	flags := make(map[string]string)
+	if settings.MinWordLength != nil {
+		flags["min-word-length"] = strconv.Itoa(*settings.MinWordLength)
+	}
+	if settings.ExcludeWords != nil {
+		flags["exclude-words"] = strings.Join(settings.ExcludeWords, ",")
+	}
+	if settings.Keywords != nil {
+		flags["keywords"] = strings.Join(settings.Keywords, ",")
+	}
+	return flags
+}
+
+// Plugin is the golangci-lint module plugin providing the spellchecker analyzers.
+type Plugin struct {
+	settings Settings
+}
+
+// New creates a new plugin from the raw settings passed by golangci-lint.
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, err
+	}
+	return &Plugin{settings: s}, nil
+}
+
+// BuildAnalyzers returns the analyzers of the plugin, configured according to the settings.
+// These are SpellcheckerPackageComments, SpellcheckerImportComments and SpellcheckerWords.
+func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	flags := p.settings.flags()
+
+	analyzers := []*analysis.Analyzer{
+		spellchecker.CloneAnalyzer(spellchecker.SpellcheckerPackageComments),
+		spellchecker.CloneAnalyzer(spellchecker.SpellcheckerImportComments),
+		spellchecker.CloneAnalyzer(spellchecker.SpellcheckerWords),
+	}
+	for _, analyzer := range analyzers {
+		for name, value := range flags {
+			if err := analyzer.Flags.Set(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return analyzers, nil
+}
+
+// GetLoadMode returns the load mode required by the analyzers.
+func (p *Plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -0,0 +1,50 @@
+//spellchecker:words plugin
+package plugin
+
+//spellchecker:words testing
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		settings any
+		want     map[string]string
+		wantErr  bool
+	}{
+		{"no settings", nil, map[string]string{"min-word-length": "", "exclude-words": "", "keywords": ""}, false},
+		{"all settings", map[string]any{
+			"minWordLength": 2,
+			"excludeWords":  []any{"foo", "bar"},
+			"keywords":      []any{"cspell"},
+		}, map[string]string{"min-word-length": "2", "exclude-words": "foo,bar", "keywords": "cspell"}, false},
+		{"unknown setting", map[string]any{"minLength": 2}, nil, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := New(tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			analyzers, err := plugin.BuildAnalyzers()
+			if err != nil {
+				t.Fatalf("BuildAnalyzers() error = %v", err)
+			}
+			if len(analyzers) != 3 {
+				t.Fatalf("BuildAnalyzers() returned %d analyzers, want 3", len(analyzers))
+			}
+
+			for _, analyzer := range analyzers {
+				for name, want := range tt.want {
+					if got := analyzer.Flags.Lookup(name).Value.String(); got != want {
+						t.Errorf("%s: flag %q = %q, want %q", analyzer.Name, name, got, want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words regexp strings
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	codeSpan      = regexp.MustCompile("`[^`]*`")
+	urlPattern    = regexp.MustCompile(`\b(?:[a-zA-Z][a-zA-Z0-9+.-]*://|www\.|mailto:)[^\s<>"'` + "`" + `]+`)
+	qualifiedName = regexp.MustCompile(`\b[\pL\pN_]+(?:\.[\pL\pN_]+)+\b`)
+	proseWord     = regexp.MustCompile(`\pL+(?:['’]\pL+)*`)
+)
+
+// maskProse replaces parts of prose text that should not be spell-checked with spaces.
+// These are code spans, urls and qualified names such as 'fmt.Println'.
+//
+// The returned string has the same length as text, so that offsets remain valid.
+func maskProse(text string) string {
+	for _, pattern := range []*regexp.Regexp{codeSpan, urlPattern, qualifiedName} {
+		text = maskPattern(text, pattern)
+	}
+	return text
+}
+
+// maskPattern replaces all matches of pattern in text with spaces.
+func maskPattern(text string, pattern *regexp.Regexp) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		return strings.Repeat(" ", len(match))
+	})
+}
+
+// forEachProseWord calls f for each word in text, along with the byte offset of the word.
+//
+// Unlike forEachWord, words containing apostrophes (such as "doesn't") are passed to f as a single word.
+// All other words are split using SplitWordsWith and opts.
+func forEachProseWord(text string, opts SplitOptions, f func(offset int, word string)) {
+	for _, match := range proseWord.FindAllStringIndex(text, -1) {
+		word := text[match[0]:match[1]]
+		if strings.ContainsAny(word, "'’") {
+			f(match[0], word)
+			continue
+		}
+
+		forEachWord(word, opts, func(offset int, word string) {
+			f(match[0]+offset, word)
+		})
+	}
+}
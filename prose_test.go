@@ -0,0 +1,37 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words reflect testing
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForEachProseWord(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "hello world", want: []string{"hello", "world"}},
+		{text: "it doesn't HelloWorld", want: []string{"it", "doesn't", "Hello", "World"}},
+		{text: "see `code span` here", want: []string{"see", "here"}},
+		{text: "visit https://example.com/path now", want: []string{"visit", "now"}},
+		{text: "call fmt.Println first", want: []string{"call", "first"}},
+		{text: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got []string
+			masked := maskProse(tt.text)
+			forEachProseWord(masked, SplitOptions{}, func(offset int, word string) {
+				if tt.text[offset:offset+len(word)] != word {
+					t.Errorf("forEachProseWord() reported word %q at wrong offset %d", word, offset)
+				}
+				got = append(got, word)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("forEachProseWord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
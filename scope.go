@@ -0,0 +1,89 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token
+import (
+	"go/ast"
+	"go/token"
+)
+
+// scope determines the parts of a file the spellchecker is disabled in.
+// See [Scope].
+type scope struct {
+	disabled [][2]token.Pos // half-open regions [start, end) in which the spellchecker is disabled
+}
+
+// scopeError is a problem with the disable and enable directives of a file.
+type scopeError struct {
+	directive directive
+	message   string
+	remove    bool // should the directive be removed to fix the error?
+}
+
+// newScope determines the scope of the spellchecker in file, and any problems with unbalanced directives.
+//
+// An unterminated 'disable' directive is only considered a problem if the file contains an 'enable' directive.
+// Otherwise it is assumed to intentionally disable the rest of the file.
+func newScope(cfg *Config, fset *token.FileSet, file *ast.File) (sc scope, errors []scopeError) {
+	tokenFile := fset.File(file.Pos())
+
+	// lineRegion returns the region spanning the given line.
+	lineRegion := func(line int) [2]token.Pos {
+		if line > tokenFile.LineCount() {
+			return [2]token.Pos{token.NoPos, token.NoPos}
+		}
+
+		end := token.Pos(tokenFile.Base() + tokenFile.Size() + 1)
+		if line < tokenFile.LineCount() {
+			end = tokenFile.LineStart(line + 1)
+		}
+		return [2]token.Pos{tokenFile.LineStart(line), end}
+	}
+
+	var (
+		open      *directive // the 'disable' directive of the current region, if any
+		hadEnable bool       // did we see an 'enable' directive?
+	)
+	forEachDirective(cfg, file, func(d directive) {
+		switch d.Scope() {
+		case ScopeDisable:
+			if open != nil {
+				errors = append(errors, scopeError{directive: d, message: "'disable' directive in region that is already disabled", remove: true})
+				return
+			}
+			open = &d
+		case ScopeEnable:
+			hadEnable = true
+			if open == nil {
+				errors = append(errors, scopeError{directive: d, message: "'enable' directive without preceding 'disable' directive", remove: true})
+				return
+			}
+			sc.disabled = append(sc.disabled, [2]token.Pos{open.pos, d.end})
+			open = nil
+		case ScopeDisableLine:
+			sc.disabled = append(sc.disabled, lineRegion(tokenFile.Line(d.pos)))
+		case ScopeDisableNextLine:
+			sc.disabled = append(sc.disabled, lineRegion(tokenFile.Line(d.end)+1))
+		}
+	})
+
+	// close the last region at the end of the file
+	if open != nil {
+		sc.disabled = append(sc.disabled, [2]token.Pos{open.pos, token.Pos(tokenFile.Base() + tokenFile.Size() + 1)})
+		if hadEnable {
+			errors = append(errors, scopeError{directive: *open, message: "'disable' directive without matching 'enable' directive"})
+		}
+	}
+
+	return sc, errors
+}
+
+// Disabled checks if the spellchecker is disabled at the given position.
+func (sc scope) Disabled(pos token.Pos) bool {
+	for _, region := range sc.disabled {
+		if region[0] <= pos && pos < region[1] {
+			return true
+		}
+	}
+	return false
+}
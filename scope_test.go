@@ -0,0 +1,57 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words parser token testing
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestNewScope(t *testing.T) {
+	const source = `package example
+
+var a = 1 // spellchecker:disable-line
+var b = 2
+
+//spellchecker:disable-next-line
+var c = 3
+var d = 4
+
+//spellchecker:disable
+var e = 5
+//spellchecker:enable
+var f = 6
+
+//spellchecker:enable
+//spellchecker:disable
+var g = 7
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc, problems := newScope(DefaultConfig(), fset, file)
+
+	wantDisabled := map[string]bool{"a": true, "b": false, "c": true, "d": false, "e": true, "f": false, "g": true}
+	for _, decl := range file.Decls {
+		name := decl.(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Names[0]
+		if got := sc.Disabled(name.Pos()); got != wantDisabled[name.Name] {
+			t.Errorf("Disabled(%s) = %v, want %v", name.Name, got, wantDisabled[name.Name])
+		}
+	}
+
+	wantProblems := []int{15, 16}
+	if len(problems) != len(wantProblems) {
+		t.Fatalf("newScope() returned %d problems, want %d", len(problems), len(wantProblems))
+	}
+	for i, problem := range problems {
+		if line := fset.Position(problem.directive.pos).Line; line != wantProblems[i] {
+			t.Errorf("newScope() problem %d on line %d, want %d", i, line, wantProblems[i])
+		}
+	}
+}
@@ -0,0 +1,101 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words token strconv strings
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// structTagPart is a key or value of a struct tag.
+type structTagPart struct {
+	key    string // the key the part belongs to
+	text   string // the text of the part, with escape sequences in values resolved
+	offset int    // byte offset of text in the tag, or -1 if the text does not occur literally in it
+}
+
+// parseStructTag parses tag into its keys and values, using the conventional format understood by [reflect.StructTag].
+// Parsing stops at the first malformed key-value pair.
+func parseStructTag(tag string) (parts []structTagPart) {
+	offset := 0
+	for {
+		// skip leading space
+		for offset < len(tag) && tag[offset] == ' ' {
+			offset++
+		}
+		if offset == len(tag) {
+			return parts
+		}
+
+		// scan to colon, a space, a quote or a control character is a syntax error
+		start := offset
+		for offset < len(tag) && tag[offset] > ' ' && tag[offset] != ':' && tag[offset] != '"' && tag[offset] != 0x7f {
+			offset++
+		}
+		if offset == start || offset+1 >= len(tag) || tag[offset] != ':' || tag[offset+1] != '"' {
+			return parts
+		}
+		key := tag[start:offset]
+		parts = append(parts, structTagPart{key: key, text: key, offset: start})
+
+		// scan quoted string to find value
+		offset++
+		start = offset
+		offset++
+		for offset < len(tag) && tag[offset] != '"' {
+			if tag[offset] == '\\' {
+				offset++
+			}
+			offset++
+		}
+		if offset >= len(tag) {
+			return parts
+		}
+		offset++
+
+		value, err := strconv.Unquote(tag[start:offset])
+		if err != nil {
+			return parts
+		}
+
+		part := structTagPart{key: key, text: value, offset: start + 1}
+		if strings.ContainsRune(tag[start:offset], '\\') {
+			part.offset = -1
+		}
+		parts = append(parts, part)
+	}
+}
+
+// forEachStructTagPart calls f for each key and value of the struct tag lit, along with the position of the part.
+// If a part does not occur literally in the source, such as when escape sequences are used, the position is [token.NoPos].
+func forEachStructTagPart(lit *ast.BasicLit, f func(pos token.Pos, part structTagPart)) {
+	tag, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return
+	}
+
+	// offsets in raw strings are literal, except for carriage returns that are removed
+	literal := strings.HasPrefix(lit.Value, "`") && !strings.ContainsRune(lit.Value, '\r')
+
+	for _, part := range parseStructTag(tag) {
+		if !literal || part.offset < 0 {
+			f(token.NoPos, part)
+			continue
+		}
+		f(lit.Pos()+1+token.Pos(part.offset), part)
+	}
+}
+
+// structTags returns the set of struct tags in node.
+func structTags(node ast.Node) map[*ast.BasicLit]struct{} {
+	tags := make(map[*ast.BasicLit]struct{})
+	ast.Inspect(node, func(node ast.Node) bool {
+		if field, ok := node.(*ast.Field); ok && field.Tag != nil {
+			tags[field.Tag] = struct{}{}
+		}
+		return true
+	})
+	return tags
+}
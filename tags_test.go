@@ -0,0 +1,45 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words reflect testing
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseStructTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []structTagPart
+	}{
+		{tag: "", want: nil},
+		{
+			tag: `json:"userUUID,omitempty" yaml:"authz_endpoint"`,
+			want: []structTagPart{
+				{key: "json", text: "json", offset: 0},
+				{key: "json", text: "userUUID,omitempty", offset: 6},
+				{key: "yaml", text: "yaml", offset: 26},
+				{key: "yaml", text: "authz_endpoint", offset: 32},
+			},
+		},
+		{
+			tag: `json:"a\"b"`,
+			want: []structTagPart{
+				{key: "json", text: "json", offset: 0},
+				{key: "json", text: `a"b`, offset: -1},
+			},
+		},
+		{
+			tag:  `json:"a" malformed yaml:"b"`,
+			want: []structTagPart{{key: "json", text: "json", offset: 0}, {key: "json", text: "a", offset: 6}},
+		},
+		{tag: `json:"unterminated`, want: []structTagPart{{key: "json", text: "json", offset: 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := parseStructTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStructTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//spellchecker:words blockdirectives
+package blockdirectives
+
+// want +2 `directives should use line comments`
+
+/*
+spellchecker:ignore  foo   bar
+spellchecker:flagWords baz
+*/
+
+// comments with unknown directives are not converted, so that the fixes do not overlap
+// want +2 `unknown directive 'wrods', did you mean 'words'\?`
+
+/* spellchecker:wrods frobnicate
+spellchecker:ignore foo */
+
+// problems without a fix are still reported in converted comments
+// want +2 `directives should use line comments` `invalid regular expression in 'ignoreRegExp' directive`
+
+/* spellchecker:ignoreRegExp /[/ */
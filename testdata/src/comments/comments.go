@@ -0,0 +1,45 @@
+//spellchecker:words comments
+package comments
+
+//spellchecker:words frobnicate
+
+// Frobnicate frobnicates the qwzx value. // want `unknown word "qwzx" in comment`
+//
+// Indented code blocks are skipped:
+//
+//	qwzxValue := Frobnicate()
+//
+// So are code spans like `qwzxValue`, urls like https://example.com/qwzx
+// and qualified names like qwzx.Value.
+func Frobnicate() int { return 0 }
+
+//go:generate qwzx
+
+// want +3 `unknown word "zxqw" in comment`
+// want +8 `unknown word "qwzx" in comment`
+/*
+Block comments are checked line by line, zxqw.
+
+	qwzxValue := Frobnicate()
+
+	qwzxOther := Frobnicate()
+
+Indented code blocks are skipped, but not this qwzx.
+*/
+var Value = Frobnicate()
+
+// want +2 `unknown word "zxqw" in comment`
+/*
+ * Decorated block comments are checked, zxqw.
+ *
+ *	qwzxValue := Frobnicate()
+ */
+var Other = Frobnicate() /* Inline qwzx. */ // want `unknown word "qwzx" in comment`
+
+func local() {
+	// want +2 `unknown word "zxqw" in comment`
+	/*
+		Indented block comments keep their prose, zxqw.
+			qwzxValue := Frobnicate()
+	*/
+}
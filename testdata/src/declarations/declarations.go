@@ -0,0 +1,33 @@
+//spellchecker:words declarations
+package declarations
+
+// want +2 `missing 'spellchecker:words' directive in declaration doc`
+
+func qwzxFunc() {}
+
+// want +3 `missing 'spellchecker:words' directive in declaration doc`
+
+// Thing is documented.
+type Thing struct {
+	Zxqw int
+}
+
+// want +2 `missing 'spellchecker:words' directive in declaration doc`
+
+var (
+	qwzxValue = 1
+	zxqwValue = 2
+)
+
+// want +2 `'spellchecker:words' directive in declaration doc should only contain declaration words`
+
+//spellchecker:words qwzx
+func qwzxZxqw() {}
+
+// want +2 `'spellchecker:words' directive in declaration doc should only refer to declaration words`
+
+//spellchecker:words qwzx
+func known() {}
+
+//spellchecker:words qwzx
+const qwzxConst = 1
@@ -0,0 +1,10 @@
+//spellchecker:words directives
+package directives
+
+// want +2 `unknown directive 'wrods', did you mean 'words'\?`
+
+//spellchecker:wrods frobnicate
+
+// want +2 `unknown directive 'ignore-string', did you mean 'ignore-strings'\?`
+
+/* spellchecker:ignore-string */
@@ -0,0 +1,30 @@
+//spellchecker:words identifiers
+package identifiers
+
+//spellchecker:words frobnicate
+//spellchecker:flagWords widget
+//spellchecker:ignoreRegExp /xyz[a-z]+/
+
+// known words and their inflections
+var parsedValues []int
+
+// words listed in a directive and their inflections
+var frobnicatedValues []int
+
+func qwzxValue() {} // want `unknown word "qwzx" in identifier "qwzxValue"`
+
+var frobnicateWidget int // want `forbidden word "Widget" in identifier "frobnicateWidget"`
+
+type Thing struct {
+	Qwzx int // want `unknown word "Qwzx" in identifier "Qwzx"`
+}
+
+// references to declared identifiers are not checked
+func useThing() {
+	qwzxValue()
+}
+
+var qwzxDisabled int //spellchecker:disable-line
+
+// parts matching an 'ignoreRegExp' directive are masked
+var xyzqwzxValue int
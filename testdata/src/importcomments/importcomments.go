@@ -0,0 +1,16 @@
+//spellchecker:words importcomments
+package importcomments
+
+import ( // want `missing 'spellchecker:words' directive in import doc`
+	"strings"
+	"unicode/utf8"
+)
+
+// want +2 `'spellchecker:words' directive in import doc should only contain import words`
+
+//spellchecker:words strconv unicode
+import "strconv"
+
+var _ = strings.ToUpper
+var _ = utf8.RuneLen
+var _ = strconv.Itoa
@@ -0,0 +1,8 @@
+//spellchecker:words nostrings
+package nostrings
+
+var (
+	greeting = "hello wrold"
+	raw      = `hello\nwrold`
+	format   = "%d qwzx items"
+)
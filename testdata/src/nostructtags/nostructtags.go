@@ -0,0 +1,9 @@
+//spellchecker:words nostructtags
+package nostructtags
+
+// words of struct tags are neither checked nor added to directives
+
+//spellchecker:words qwzx
+type Thing struct {
+	qwzxName string `field:"zxqwName" zxqwkey:"value"`
+}
@@ -0,0 +1,6 @@
+// want +4 `'spellchecker:words' directive in header doc should only contain package words`
+
+// Package packagecomments is used to test fixes of package comments.
+//
+//spellchecker:words outdated
+package packagecomments
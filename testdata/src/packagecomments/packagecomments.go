@@ -0,0 +1 @@
+package packagecomments // want `missing 'spellchecker:words' directive for package documentation`
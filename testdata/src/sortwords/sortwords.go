@@ -0,0 +1,18 @@
+//spellchecker:words sortwords
+package sortwords
+
+// want +2 `improperly formatted 'words' directive`
+
+//spellchecker:words zorblify Blorpify frobnicate
+
+var zorblify, blorpify, frobnicate = 1, 2, 3
+
+// want +2 `'words' directive: word "zorbl.fy" is already listed in another 'words' directive`
+
+//spellchecker:words zorblify quxify
+
+var quxify = 4
+
+// want +2 `'words' directive: words "sortw.rds", "blorp.fy", "frobn.cate" are already listed in another 'words' directive`
+
+//spellchecker:words sortwords blorpify frobnicate
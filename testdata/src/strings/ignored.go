@@ -0,0 +1,9 @@
+//spellchecker:words strings
+package strings
+
+//spellchecker:ignore-strings
+
+var (
+	ignored = "hello wrold"
+	also    = `qwzx items`
+)
@@ -0,0 +1,21 @@
+//spellchecker:words strings
+package strings
+
+//spellchecker:check-strings
+//spellchecker:words frobnicate
+
+import "fmt"
+
+var (
+	greeting = "hello wrold"  // want `unknown word "wrold" in string`
+	raw      = `hello\nwrold` // want `unknown word "wrold" in string`
+	escaped  = "hello\twrold" // want `unknown word "wrold" in string`
+	known    = "frobnicated values"
+	format   = fmt.Sprintf("%d qwzx items", 1) // want `unknown word "qwzx" in string`
+	paths    = "see /usr/qwzx/file and C:\\qwzx\\file"
+	disabled = "qwzx" //spellchecker:disable-line
+)
+
+type Value struct {
+	Name string `json:"qwzx"`
+}
@@ -0,0 +1,9 @@
+//spellchecker:words declarations
+package declarations
+
+// want +2 `missing 'spellchecker:words' directive in declaration doc`
+
+type Thing struct {
+	Name  string `field:"qwzxName"`
+	Value string `zxqwkey:"value"`
+}
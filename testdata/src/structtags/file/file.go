@@ -0,0 +1,6 @@
+package file // want `missing 'spellchecker:words' directive for package documentation`
+
+type Thing struct {
+	Name  string `field:"qwzxName"`
+	Value string `zxqwkey:"value"`
+}
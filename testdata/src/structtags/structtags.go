@@ -0,0 +1,14 @@
+//spellchecker:words structtags
+package structtags
+
+type tagged struct {
+	// words of raw struct tags are reported at their position
+	Raw string `field:"qwzxName" zxqwkey:"value"` // want `unknown word "qwzx" in struct tag "field"` `unknown word "zxqwkey" in struct tag "zxqwkey"`
+
+	// interpreted struct tags, and values with escape sequences, are reported for the entire tag
+	Interpreted string "field:\"zxqwName\"" // want `unknown word "zxqw" in struct tag "field"`
+	Escaped     string `field:"qwzx\"name"` // want `unknown word "qwzx" in struct tag "field"`
+
+	// known words are not reported
+	Known string `field:"knownName"`
+}
@@ -0,0 +1,6 @@
+//spellchecker:words tofile strings qwzx
+package tofile
+
+import "strings"
+
+var qwzxUpper = strings.ToUpper
@@ -0,0 +1,5 @@
+package tofile // want `missing 'spellchecker:words' directive for package documentation`
+
+import "bytes"
+
+var _ = bytes.ToUpper
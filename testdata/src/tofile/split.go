@@ -0,0 +1,14 @@
+// want +2 `'spellchecker:words' directive in header doc should only contain file words`
+
+//spellchecker:words tofile
+package tofile
+
+// want +2 `'spellchecker:words' directive in import doc is not used with the file layout`
+
+//spellchecker:words strconv unicode
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+var qwzxValue = strconv.Itoa(utf8.RuneLen('a'))
@@ -0,0 +1,9 @@
+//spellchecker:words importcomments strconv unicode
+package importcomments
+
+import ( // want `missing 'spellchecker:words' directive in import doc`
+	"strconv"
+	"unicode/utf8"
+)
+
+var _ = strconv.Itoa(utf8.RuneLen('a'))
@@ -0,0 +1,11 @@
+// want +2 `'spellchecker:words' directive in header doc should only contain package words`
+
+//spellchecker:words packagecomments strconv unicode
+package packagecomments
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+var _ = strconv.Itoa(utf8.RuneLen('a'))
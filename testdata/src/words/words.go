@@ -0,0 +1,20 @@
+//spellchecker:words words
+package words
+
+// want +2 `'words' directive: word "frobnicate" is listed more than once`
+
+//spellchecker:words frobnicate widget frobnicate
+
+// Frobnicate frobnicates the widget.
+func Frobnicate() {}
+
+// only an inflection of the listed word occurs, so it is still needed
+//spellchecker:words zorblify
+
+var zorblified = true
+
+// want +2 `'words' directive: word "qux.fy" does not occur in the file`
+
+//spellchecker:words blorpify quxify
+
+var blorpifies = false
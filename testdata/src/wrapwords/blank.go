@@ -0,0 +1,12 @@
+// want +2 `'spellchecker:words' directive in header doc should only contain file words`
+
+//spellchecker:words wrapwords
+
+// want +2 `there should be at most one 'spellchecker:words' directive in header`
+
+//spellchecker:words bytes
+package wrapwords
+
+import "bytes"
+
+var _ = bytes.ToUpper
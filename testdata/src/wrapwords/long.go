@@ -0,0 +1,6 @@
+// want +2 `'spellchecker:words' directive in header doc should only contain file words`
+
+//spellchecker:words wrapwords qwzx zorblify
+package wrapwords
+
+var qwzxOther, zorblifyOther = 1, 2
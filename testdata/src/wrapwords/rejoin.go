@@ -0,0 +1,9 @@
+// want +2 `'spellchecker:words' directive in header doc should only contain file words`
+
+//spellchecker:words wrapwords
+//spellchecker:words strconv
+package wrapwords
+
+import "strconv"
+
+var _ = strconv.Itoa
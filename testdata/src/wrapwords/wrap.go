@@ -0,0 +1,3 @@
+package wrapwords // want `missing 'spellchecker:words' directive for package documentation`
+
+var qwzxValue, zorblifyValue = 1, 2
@@ -0,0 +1,5 @@
+//spellchecker:words wrapwords qwzx
+//spellchecker:words zorblify
+package wrapwords
+
+var qwzxWrapped, zorblifyWrapped = 1, 2
@@ -0,0 +1,244 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words strings unicode
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CommentSyntax describes the comments of a file type other than Go, see [Config.CheckText].
+type CommentSyntax struct {
+	// Line is the prefix of line comments, such as '#' or '--'.
+	// If empty, the file type has no line comments.
+	Line string
+
+	// BlockStart and BlockEnd are the start and end of block comments, such as '<!--' and '-->'.
+	// If either is empty, the file type has no block comments.
+	BlockStart, BlockEnd string
+}
+
+// ParseCommentSyntax parses a comment syntax of the form 'line', 'start end' or 'line start end',
+// such as '#', '<!-- -->' or '-- /* */'.
+func ParseCommentSyntax(text string) (CommentSyntax, error) {
+	fields := strings.Fields(text)
+	switch len(fields) {
+	case 1:
+		return CommentSyntax{Line: fields[0]}, nil
+	case 2:
+		return CommentSyntax{BlockStart: fields[0], BlockEnd: fields[1]}, nil
+	case 3:
+		return CommentSyntax{Line: fields[0], BlockStart: fields[1], BlockEnd: fields[2]}, nil
+	default:
+		return CommentSyntax{}, fmt.Errorf("invalid comment syntax %q: expected 'line', 'start end' or 'line start end'", text)
+	}
+}
+
+// TextDiagnostic is a problem with a directive in a text file.
+// Positions are byte offsets into the text.
+type TextDiagnostic struct {
+	Start, End int
+	Category   string
+	Message    string
+
+	// Fix is the suggested fix of the problem, or nil if there is none.
+	Fix *TextFix
+}
+
+// TextFix is a suggested fix of a [TextDiagnostic], replacing the text between Start and End by NewText.
+type TextFix struct {
+	Message    string
+	Start, End int
+	NewText    string
+}
+
+// textDirective is a directive found in a text file.
+type textDirective struct {
+	CommentText
+
+	start, end int    // byte offsets of the directive, excluding surrounding spaces and comment delimiters
+	text       string // the text of the directive
+
+	commentStart, commentEnd int  // byte offsets of the comment containing the directive, including delimiters
+	block                    bool // is the directive part of a block comment?
+}
+
+// CheckText validates and normalizes the spellchecker directives in text, a file using the given comment syntax.
+//
+// The same diagnostics as reported by [SpellcheckerDirectives] are returned.
+// In addition, 'spellchecker:words' directives are normalized, and all other directives use the canonical keyword and directive name.
+func (cfg *Config) CheckText(text string, syntax CommentSyntax) (diagnostics []TextDiagnostic) {
+	for _, d := range cfg.textDirectives(text, syntax) {
+		diagnostics = append(diagnostics, cfg.checkTextDirective(text, syntax, d)...)
+	}
+	return diagnostics
+}
+
+// checkTextDirective validates and normalizes the directive d of text, see [Config.CheckText].
+func (cfg *Config) checkTextDirective(text string, syntax CommentSyntax, d textDirective) (diagnostics []TextDiagnostic) {
+	var problems []directiveProblem
+	if d.IsDirective("words") {
+		problems = validateWordsDirective(cfg, d.CommentText)
+	} else {
+		problems = validateDirective(cfg, d.CommentText, true)
+	}
+
+	for _, problem := range problems {
+		diagnostic := TextDiagnostic{Start: d.start, End: d.end, Category: CategoryDirective, Message: problem.message}
+		switch {
+		case problem.fix == "":
+			// no suggested fix
+		case problem.text == "":
+			start, end := d.removal(text, syntax)
+			diagnostic.Fix = &TextFix{Message: problem.fix, Start: start, End: end}
+		case problem.text == d.text:
+			continue
+		default:
+			diagnostic.Fix = &TextFix{Message: problem.fix, Start: d.start, End: d.end, NewText: problem.text}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// validateWordsDirective validates the 'words' directive ct.
+func validateWordsDirective(cfg *Config, ct CommentText) []directiveProblem {
+	words := ct.Words()
+	if len(words) == 0 {
+		return []directiveProblem{{message: "empty words directive", fix: "remove comment"}}
+	}
+	return []directiveProblem{{
+		message: "improperly formatted 'words' directive",
+		fix:     "reformat 'words' directive",
+		text:    cfg.formatWordsDirective(words),
+	}}
+}
+
+// textDirectives returns the directives in the comments of text, in the order they occur in.
+//
+// A line comment contains at most one directive, and starts at the beginning of a line or after a space.
+// A block comment may contain one directive per line.
+func (cfg *Config) textDirectives(text string, syntax CommentSyntax) (directives []textDirective) {
+	add := func(offset int, line string, commentStart, commentEnd int, block bool) {
+		start, trimmed := trimBlockLine(line)
+		if ct, ok := cfg.Parse(trimmed); ok {
+			directives = append(directives, textDirective{
+				CommentText:  ct,
+				start:        offset + start,
+				end:          offset + start + len(trimmed),
+				text:         trimmed,
+				commentStart: commentStart,
+				commentEnd:   commentEnd,
+				block:        block,
+			})
+		}
+	}
+
+	offset := 0
+	for offset < len(text) {
+		lineEnd := strings.IndexByte(text[offset:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(text)
+		} else {
+			lineEnd += offset
+		}
+		line := strings.TrimRight(text[offset:lineEnd], "\r")
+
+		lineStart, blockStart := syntax.findLine(line), syntax.findBlock(line)
+		switch {
+		case blockStart >= 0 && (lineStart < 0 || blockStart < lineStart):
+			// block comment, possibly spanning multiple lines
+			start := offset + blockStart + len(syntax.BlockStart)
+			end := strings.Index(text[start:], syntax.BlockEnd)
+			if end < 0 {
+				end = len(text)
+			} else {
+				end += start
+			}
+
+			commentStart, commentEnd := offset+blockStart, min(len(text), end+len(syntax.BlockEnd))
+			for line := range strings.SplitAfterSeq(text[start:end], "\n") {
+				add(start, strings.TrimRight(line, "\r\n"), commentStart, commentEnd, true)
+				start += len(line)
+			}
+
+			// continue after the end of the comment
+			offset = commentEnd
+			continue
+
+		case lineStart >= 0:
+			add(offset+lineStart+len(syntax.Line), line[lineStart+len(syntax.Line):], offset+lineStart, offset+len(line), false)
+		}
+
+		offset = lineEnd + 1
+	}
+	return directives
+}
+
+// findLine returns the index of the first line comment in line, or -1 if there is none.
+func (syntax CommentSyntax) findLine(line string) int {
+	return findCommentStart(line, syntax.Line)
+}
+
+// findBlock returns the index of the first block comment in line, or -1 if there is none.
+func (syntax CommentSyntax) findBlock(line string) int {
+	if syntax.BlockEnd == "" {
+		return -1
+	}
+	return findCommentStart(line, syntax.BlockStart)
+}
+
+// removal returns the range of text to remove in order to remove the directive d.
+//
+// Line comments are removed entirely, as are block comments containing nothing but d.
+// If nothing else is on the same line, the entire line is removed, otherwise spaces preceding a comment at the end of a line.
+func (d textDirective) removal(text string, syntax CommentSyntax) (start, end int) {
+	start, end = d.commentStart, d.commentEnd
+	if d.block {
+		content := text[start+len(syntax.BlockStart) : max(start+len(syntax.BlockStart), end-len(syntax.BlockEnd))]
+		if strings.TrimSpace(strings.Replace(content, d.text, "", 1)) != "" {
+			start, end = d.start, d.end
+		}
+	}
+
+	lineStart := strings.LastIndexByte(text[:start], '\n') + 1
+	lineEnd := strings.IndexByte(text[end:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += end + 1
+	}
+	if strings.TrimSpace(text[end:lineEnd]) != "" {
+		return start, end
+	}
+	if strings.TrimSpace(text[lineStart:start]) == "" {
+		return lineStart, lineEnd
+	}
+
+	// remove trailing spaces left on the line
+	for start > lineStart && (text[start-1] == ' ' || text[start-1] == '\t') {
+		start--
+	}
+	return start, end
+}
+
+// findCommentStart returns the index of the first occurrence of delimiter in line that is at the start of the line or follows a space.
+// If there is none, or delimiter is empty, returns -1.
+func findCommentStart(line, delimiter string) int {
+	if delimiter == "" {
+		return -1
+	}
+	for offset := 0; offset < len(line); {
+		index := strings.Index(line[offset:], delimiter)
+		if index < 0 {
+			return -1
+		}
+		index += offset
+		if index == 0 || unicode.IsSpace(rune(line[index-1])) {
+			return index
+		}
+		offset = index + len(delimiter)
+	}
+	return -1
+}
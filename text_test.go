@@ -0,0 +1,126 @@
+//spellchecker:words spellchecker
+package spellchecker
+
+//spellchecker:words reflect slices testing
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseCommentSyntax(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    CommentSyntax
+		wantErr bool
+	}{
+		{text: "#", want: CommentSyntax{Line: "#"}},
+		{text: " <!--  --> ", want: CommentSyntax{BlockStart: "<!--", BlockEnd: "-->"}},
+		{text: "-- /* */", want: CommentSyntax{Line: "--", BlockStart: "/*", BlockEnd: "*/"}},
+		{text: "", wantErr: true},
+		{text: "a b c d", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseCommentSyntax(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCommentSyntax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCommentSyntax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckText(t *testing.T) {
+	var (
+		hash = CommentSyntax{Line: "#"}
+		sql  = CommentSyntax{Line: "--", BlockStart: "/*", BlockEnd: "*/"}
+		html = CommentSyntax{BlockStart: "<!--", BlockEnd: "-->"}
+	)
+
+	tests := []struct {
+		name         string
+		text         string
+		syntax       CommentSyntax
+		wantMessages []string
+		wantFixed    string
+	}{
+		{
+			name:      "valid directives",
+			text:      "# spellchecker:words hello world\nkey: value # spellchecker:disable-line\n",
+			syntax:    hash,
+			wantFixed: "# spellchecker:words hello world\nkey: value # spellchecker:disable-line\n",
+		},
+		{
+			name:         "normalize directives",
+			text:         "# cspell:words world hello  world\nkey: value # CSPELL:Disable-Line\n",
+			syntax:       hash,
+			wantMessages: []string{"improperly formatted 'words' directive", "improperly formatted 'disable-line' directive"},
+			wantFixed:    "# spellchecker:words world hello\nkey: value # spellchecker:disable-line\n",
+		},
+		{
+			name:         "no comment",
+			text:         "url: http://example.com#cspell:wrods\n",
+			syntax:       hash,
+			wantMessages: nil,
+			wantFixed:    "url: http://example.com#cspell:wrods\n",
+		},
+		{
+			name:         "unknown directive",
+			text:         "-- spellchecker:wrods hello\n-- spellchecker:frobnicate\nSELECT 1;\n",
+			syntax:       sql,
+			wantMessages: []string{"unknown directive 'wrods', did you mean 'words'?", "unknown directive 'frobnicate'"},
+			wantFixed:    "-- spellchecker:words hello\n-- spellchecker:frobnicate\nSELECT 1;\n",
+		},
+		{
+			name:         "remove empty line directive",
+			text:         "SELECT 1;\n-- spellchecker:ignore\nSELECT 2; -- spellchecker:words\n",
+			syntax:       sql,
+			wantMessages: []string{"empty 'ignore' directive", "empty words directive"},
+			wantFixed:    "SELECT 1;\nSELECT 2;\n",
+		},
+		{
+			name:         "block comments",
+			text:         "<!-- cspell:ignore foo  bar -->\n<!--\n  spellchecker:language en , de\n  spellchecker:ignoreRegExp\n-->\n# Title <!-- spellchecker:ignore -->\n",
+			syntax:       html,
+			wantMessages: []string{"improperly formatted 'ignore' directive", "improperly formatted 'language' directive", "empty 'ignoreRegExp' directive", "empty 'ignore' directive"},
+			wantFixed:    "<!-- spellchecker:ignore foo bar -->\n<!--\n  spellchecker:language en,de\n-->\n# Title\n",
+		},
+		{
+			name:         "invalid values",
+			text:         "/* spellchecker:ignoreRegExp /[/ */\n-- spellchecker:dictionaries frobnicate\n",
+			syntax:       sql,
+			wantMessages: []string{"invalid regular expression in 'ignoreRegExp' directive: error parsing regexp: missing closing ]: `[`", "unknown dictionary \"frobnicate\" in 'dictionaries' directive"},
+			wantFixed:    "/* spellchecker:ignoreRegExp /[/ */\n-- spellchecker:dictionaries frobnicate\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := DefaultConfig().CheckText(tt.text, tt.syntax)
+
+			var messages []string
+			var fixes []TextFix
+			for _, diagnostic := range diagnostics {
+				messages = append(messages, diagnostic.Message)
+				if diagnostic.Fix != nil {
+					fixes = append(fixes, *diagnostic.Fix)
+				}
+			}
+			if !reflect.DeepEqual(messages, tt.wantMessages) {
+				t.Errorf("CheckText() messages = %q, want %q", messages, tt.wantMessages)
+			}
+
+			// apply the fixes back to front
+			fixed := tt.text
+			slices.SortFunc(fixes, func(a, b TextFix) int { return b.Start - a.Start })
+			for _, fix := range fixes {
+				fixed = fixed[:fix.Start] + fix.NewText + fixed[fix.End:]
+			}
+			if fixed != tt.wantFixed {
+				t.Errorf("CheckText() fixed = %q, want %q", fixed, tt.wantFixed)
+			}
+		})
+	}
+}
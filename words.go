@@ -1,8 +1,14 @@
 //spellchecker:words spellchecker
 package spellchecker
 
-//spellchecker:words unicode
-import "unicode"
+//spellchecker:words strings unicode
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//spellchecker:words HELLOworld HTTPServer HTTPURLParser OAuth
 
 // SplitWords splits text into words.
 //
@@ -13,9 +19,66 @@ import "unicode"
 // "HELLOworld" is one word, whereas "HelloWorld" is two words "Hello" and "World".
 //
 // To count the number of words in text, use CountWords instead.
+// To split words at the end of acronyms, use SplitWordsWith instead.
 func SplitWords(text string) []string {
-	// NOTE: Keep this in sync with CountWords.
-	words := make([]string, 0, CountWords(text))
+	return SplitWordsWith(text, SplitOptions{})
+}
+
+// CountWords counts the number of words in the given text.
+// It is an efficient version of len(SplitWords(text))
+func CountWords(text string) int {
+	return CountWordsWith(text, SplitOptions{})
+}
+
+// SplitOptions configure how text is split into words, see [SplitWordsWith].
+// The zero value splits text like [SplitWords].
+type SplitOptions struct {
+	// Acronyms enables splitting a run of uppercase letters before the last uppercase letter, if it is followed by lowercase letters.
+	// "HTTPServer" is then split into "HTTP" and "Server", rather than being a single word.
+	// An uppercase letter following a lowercase letter still starts a new word, so "hellO" remains "hell" and "O".
+	Acronyms bool
+
+	// Initialisms are known initialisms, such as "ID", "URL" or "OAuth", that are split off as words of their own.
+	// They only take effect if Acronyms is set.
+	//
+	// At the beginning of a word, the longest initialism is split off repeatedly,
+	// so that "URLs" is split into "URL" and "s", and "HTTPURLParser" into "HTTP", "URL" and "Parser" if both "HTTP" and "URL" are known.
+	// An initialism is only split off if the rest of the word is empty, a plural "s", starts a new capitalized word, or starts with another initialism.
+	// Words consisting only of uppercase letters are never split, so that "IDLE" and "HTTPS" remain intact.
+	// They are compared case-sensitively.
+	Initialisms []string
+}
+
+// SplitWordsWith splits text into words like [SplitWords], using the given options.
+//
+// To count the number of words in text, use CountWordsWith instead.
+func SplitWordsWith(text string, opts SplitOptions) []string {
+	words := make([]string, 0, CountWordsWith(text, opts))
+	splitWords(text, opts, func(start, end int) {
+		words = append(words, text[start:end])
+	})
+	return words
+}
+
+// CountWordsWith counts the number of words in the given text.
+// It is an efficient version of len(SplitWordsWith(text, opts))
+func CountWordsWith(text string, opts SplitOptions) int {
+	words := 0
+	splitWords(text, opts, func(start, end int) {
+		words++
+	})
+	return words
+}
+
+// splitWords calls f with the start and end byte offsets of each word in text.
+// It implements both SplitWordsWith and CountWordsWith, so that they can not get out of sync.
+func splitWords(text string, opts SplitOptions, f func(start, end int)) {
+	emit := f
+	if opts.Acronyms {
+		emit = func(start, end int) {
+			opts.splitAcronyms(text, start, end, f)
+		}
+	}
 
 	lastStart := -1       // index where the last word started
 	lastWasUpper := false // was the last letter of a word upper case?
@@ -45,10 +108,9 @@ func SplitWords(text string) []string {
 			lastWasUpper = true
 		}
 
-		// word has ended => add it to the seen ones
-		words = append(words, text[lastStart:index])
+		// word has ended
+		emit(lastStart, index)
 
-		// if we saw a letter, we have started a new word
 		// start a new word if we saw a letter
 		if isLetter {
 			lastStart = index
@@ -58,55 +120,87 @@ func SplitWords(text string) []string {
 	}
 	// finish closing the last word
 	if lastStart != -1 {
-		words = append(words, text[lastStart:])
+		emit(lastStart, len(text))
 	}
-
-	// and return the words
-	return words
 }
 
-// CountWords counts the number of words in the given text.
-// It is an efficient version of len(SplitWords(text))
-func CountWords(text string) int {
-	// NOTE: Keep this in sync with SplitWords.
-	words := 0
+// splitAcronyms splits the word text[start:end] at the end of initialisms and acronyms, and calls f for each part.
+func (opts SplitOptions) splitAcronyms(text string, start, end int, f func(start, end int)) {
+	word := text[start:end]
 
-	insideWord := false   // are we currently inside a word.
-	lastWasUpper := false // was the last letter of a word upper case?
-	for _, char := range text {
-		// not inside a word
-		if !insideWord {
-			if unicode.IsLetter(char) { // letter starts a new word
-				lastWasUpper = unicode.IsUpper(char)
-				insideWord = true
-			}
-			continue
+	// find the run of uppercase letters at the beginning of the word
+	run := 0
+	for run < len(word) {
+		char, size := utf8.DecodeRuneInString(word[run:])
+		if !unicode.IsUpper(char) {
+			break
 		}
+		run += size
+	}
 
-		// word can only continue if we had a letter
-		isLetter := unicode.IsLetter(char)
-		if isLetter {
-			// contiguous upper-case at the beginning of a word
-			if lastWasUpper && unicode.IsUpper(char) {
-				continue
-			}
+	// split off known initialisms, unless the word is entirely uppercase
+	for run < len(word) {
+		n := opts.initialism(word)
+		if n == 0 {
+			break
+		}
+		f(start, start+n)
+		start, word, run = start+n, word[n:], max(run-n, 0)
+	}
+	if word == "" {
+		return
+	}
 
-			// switched to lower-case
-			if unicode.IsLower(char) {
-				lastWasUpper = false
-				continue
-			}
-			lastWasUpper = true
+	// the last uppercase letter before a lowercase letter starts a new word
+	if run < len(word) {
+		if _, size := utf8.DecodeLastRuneInString(word[:run]); size < run {
+			f(start, start+run-size)
+			start += run - size
 		}
+	}
+	f(start, end)
+}
 
-		// word has ended => start a new one if we had a letter
-		words++
-		insideWord = isLetter
+// initialism returns the length of the longest known initialism at the beginning of word, or 0 if there is none.
+// An initialism is only split off if the rest of word is empty, a plural 's', starts a new capitalized word, or starts with another initialism that is split off.
+// This keeps longer uppercase words, such as "IDLE" or "HTTPS", intact.
+func (opts SplitOptions) initialism(word string) (n int) {
+	for _, initialism := range opts.Initialisms {
+		if len(initialism) <= n || !strings.HasPrefix(word, initialism) {
+			continue
+		}
+		if rest := word[len(initialism):]; rest == "" || rest == "s" || startsCapitalized(rest) || opts.initialism(rest) > 0 {
+			n = len(initialism)
+		}
 	}
+	return n
+}
 
-	// last word was not closed
-	if insideWord {
-		words++
+// startsCapitalized checks if text starts with an uppercase letter followed by a lowercase letter.
+func startsCapitalized(text string) bool {
+	first, size := utf8.DecodeRuneInString(text)
+	second, _ := utf8.DecodeRuneInString(text[size:])
+	return unicode.IsUpper(first) && unicode.IsLower(second)
+}
+
+// forEachWord calls f for each word in text, along with the byte offset of the word.
+func forEachWord(text string, opts SplitOptions, f func(offset int, word string)) {
+	splitWords(text, opts, func(start, end int) {
+		f(start, text[start:end])
+	})
+}
+
+// deduplicateWords removes duplicates from words, compared under case folding.
+// The first occurrence of each word is kept.
+func deduplicateWords(words []string) []string {
+	seen := make(Dictionary, len(words))
+	result := words[:0]
+	for _, word := range words {
+		if seen.ContainsExactly(word) {
+			continue
+		}
+		seen.Add(word)
+		result = append(result, word)
 	}
-	return words
+	return result
 }
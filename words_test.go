@@ -33,3 +33,51 @@ func TestSplitWords(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitWordsWith(t *testing.T) {
+	acronyms := spellchecker.SplitOptions{Acronyms: true}
+	initialisms := spellchecker.SplitOptions{Acronyms: true, Initialisms: []string{"HTTP", "URL", "ID", "OAuth"}}
+
+	tests := []struct {
+		text string
+		opts spellchecker.SplitOptions
+		want []string
+	}{
+		{text: "HTTPServer", opts: spellchecker.SplitOptions{}, want: []string{"HTTPServer"}},
+		{text: "HTTPServer", opts: acronyms, want: []string{"HTTP", "Server"}},
+		{text: "hello world", opts: acronyms, want: []string{"hello", "world"}},
+		{text: "HelloWorld", opts: acronyms, want: []string{"Hello", "World"}},
+		{text: "HelloWORLD", opts: acronyms, want: []string{"Hello", "WORLD"}},
+		{text: "HELLOworld", opts: acronyms, want: []string{"HELL", "Oworld"}},
+		{text: "hellO/world", opts: acronyms, want: []string{"hell", "O", "world"}},
+		{text: "getHTTPResponseCode", opts: acronyms, want: []string{"get", "HTTP", "Response", "Code"}},
+		{text: "URLs", opts: acronyms, want: []string{"UR", "Ls"}},
+		{text: "URLs", opts: initialisms, want: []string{"URL", "s"}},
+		{text: "userIDs", opts: initialisms, want: []string{"user", "ID", "s"}},
+		{text: "HTTPURLParser", opts: acronyms, want: []string{"HTTPURL", "Parser"}},
+		{text: "HTTPURLParser", opts: initialisms, want: []string{"HTTP", "URL", "Parser"}},
+		{text: "HTTPURLs", opts: initialisms, want: []string{"HTTP", "URL", "s"}},
+		{text: "newOAuthToken", opts: acronyms, want: []string{"new", "O", "Auth", "Token"}},
+		{text: "newOAuthToken", opts: initialisms, want: []string{"new", "OAuth", "Token"}},
+		{text: "IDENTIFIER", opts: initialisms, want: []string{"IDENTIFIER"}},
+		{text: "IDLE", opts: initialisms, want: []string{"IDLE"}},
+		{text: "HTTPS", opts: initialisms, want: []string{"HTTPS"}},
+		{text: "HTTPURL", opts: initialisms, want: []string{"HTTPURL"}},
+		{text: "IDEAPlugin", opts: initialisms, want: []string{"IDEA", "Plugin"}},
+		{text: "HTTPSServer", opts: initialisms, want: []string{"HTTPS", "Server"}},
+		{text: "IDs", opts: initialisms, want: []string{"ID", "s"}},
+		{text: "ÜBERSchrift", opts: acronyms, want: []string{"ÜBER", "Schrift"}},
+		{text: "", opts: acronyms, want: []string{}},
+		{text: "///", opts: acronyms, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := spellchecker.SplitWordsWith(tt.text, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitWordsWith() = %v, want %v", got, tt.want)
+			}
+			if got := spellchecker.CountWordsWith(tt.text, tt.opts); got != len(tt.want) {
+				t.Errorf("CountWordsWith() = %v, want %v", got, len(tt.want))
+			}
+		})
+	}
+}